@@ -1,15 +1,22 @@
 package builder
 
+import "fmt"
+
 type query interface {
 	checkTable(table *Table) bool
 	addBind(key string, value any)
+	names() *namer
+	outer() query
+	render(root *namer, outer query) (string, map[string]any, error)
 	Get() (string, map[string]any, error)
 }
 
 type set struct {
-	Column string
-	Value  interface{}
-	Now    bool
+	Column   string
+	Value    interface{}
+	Now      bool
+	Excluded bool
+	Op       string
 }
 
 type insertValue struct {
@@ -17,8 +24,52 @@ type insertValue struct {
 	Value  interface{}
 }
 
+type insertRow struct {
+	Values []any
+	Named  map[string]any
+}
+
 type Order struct {
 	Table  *Table
 	Column string
 	Desc   bool
 }
+
+func (o Order) gen(q query) (string, error) {
+	s := ""
+
+	if o.Table != nil {
+		if !q.checkTable(o.Table) {
+			return "", fmt.Errorf("table %s is not exist", o.Table)
+		}
+
+		s = o.Table.ref(q, o.Column)
+	} else {
+		s = quoteIdent(o.Column)
+	}
+
+	if o.Desc {
+		s += " DESC"
+	}
+
+	return s, nil
+}
+
+func genOrder(q query, list []Order) (string, error) {
+	s := ""
+
+	for i, o := range list {
+		sql, err := o.gen(q)
+		if err != nil {
+			return "", err
+		}
+
+		s += sql
+
+		if i != len(list)-1 {
+			s += ", "
+		}
+	}
+
+	return s, nil
+}
@@ -0,0 +1,198 @@
+package builder
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Col is a column of table with values of type T, it builds the same Where, Column, Order and On
+// values as the string based API, but a value of another type does not compile
+type Col[T any] struct {
+	Table *Table
+	Name  string
+}
+
+func NewCol[T any](table *Table, name string) Col[T] {
+	return Col[T]{Table: table, Name: name}
+}
+
+func (c Col[T]) col() (*Table, string) {
+	return c.Table, c.Name
+}
+
+func (c Col[T]) Eq(v T) Where {
+	return WhereEq{Table: c.Table, Column: c.Name, Value: v}
+}
+
+func (c Col[T]) NotEq(v T) Where {
+	return WhereNotEq{Table: c.Table, Column: c.Name, Value: v}
+}
+
+func (c Col[T]) More(v T) Where {
+	return WhereMore{Table: c.Table, Column: c.Name, Value: v}
+}
+
+func (c Col[T]) MoreEq(v T) Where {
+	return WhereMoreEq{Table: c.Table, Column: c.Name, Value: v}
+}
+
+func (c Col[T]) Less(v T) Where {
+	return WhereLess{Table: c.Table, Column: c.Name, Value: v}
+}
+
+func (c Col[T]) LessEq(v T) Where {
+	return WhereLessEq{Table: c.Table, Column: c.Name, Value: v}
+}
+
+func (c Col[T]) In(v ...T) Where {
+	return WhereIn{Table: c.Table, Column: c.Name, Values: v}
+}
+
+func (c Col[T]) IsNull() Where {
+	return WhereIsNull{Table: c.Table, Column: c.Name}
+}
+
+func (c Col[T]) IsNotNull() Where {
+	return WhereIsNotNull{Table: c.Table, Column: c.Name}
+}
+
+// EqCol compares with a column of the same type
+func (c Col[T]) EqCol(o Col[T]) Where {
+	return WhereEqColumn{Table1: c.Table, Column1: c.Name, Table2: o.Table, Column2: o.Name}
+}
+
+func (c Col[T]) NotEqCol(o Col[T]) Where {
+	return WhereNotEqColumn{Table1: c.Table, Column1: c.Name, Table2: o.Table, Column2: o.Name}
+}
+
+func (c Col[T]) MoreCol(o Col[T]) Where {
+	return WhereMoreColumn{Table1: c.Table, Column1: c.Name, Table2: o.Table, Column2: o.Name}
+}
+
+// OnEq joins on equality with a column of the same type
+func (c Col[T]) OnEq(o Col[T]) On {
+	return OnEq{Table1: c.Table, Column1: c.Name, Table2: o.Table, Column2: o.Name}
+}
+
+func (c Col[T]) OnLess(o Col[T]) On {
+	return OnLess{Table1: c.Table, Column1: c.Name, Table2: o.Table, Column2: o.Name}
+}
+
+func (c Col[T]) OnMore(o Col[T]) On {
+	return OnMore{Table1: c.Table, Column1: c.Name, Table2: o.Table, Column2: o.Name}
+}
+
+func (c Col[T]) Column() Column {
+	return ColumnName{Table: c.Table, Name: c.Name}
+}
+
+func (c Col[T]) As(alias string) Column {
+	return ColumnName{Table: c.Table, Name: c.Name, Alias: alias}
+}
+
+func (c Col[T]) Asc() Order {
+	return Order{Table: c.Table, Column: c.Name}
+}
+
+func (c Col[T]) Desc() Order {
+	return Order{Table: c.Table, Column: c.Name, Desc: true}
+}
+
+func (c Col[T]) Group() Group {
+	return GroupColumn{Table: c.Table, Column: c.Name}
+}
+
+// To assigns v to the column in UpdateQuery.Assign and InsertQuery.Assign
+func (c Col[T]) To(v T) Assignment {
+	return Assignment{Column: c.Name, Value: v}
+}
+
+// Assignment is a column value made by Col.To
+type Assignment struct {
+	Column string
+	Value  any
+}
+
+// Assign adds a Set for every assignment
+func (q *UpdateQuery) Assign(a ...Assignment) *UpdateQuery {
+	for _, v := range a {
+		q.Set(v.Column, v.Value)
+	}
+
+	return q
+}
+
+// Assign adds a Value for every assignment
+func (q *InsertQuery) Assign(a ...Assignment) *InsertQuery {
+	for _, v := range a {
+		q.Value(v.Column, v.Value)
+	}
+
+	return q
+}
+
+type typedCol interface {
+	col() (*Table, string)
+}
+
+var (
+	typedColType = reflect.TypeOf((*typedCol)(nil)).Elem()
+	tableType    = reflect.TypeOf((*Table)(nil))
+)
+
+// DefineTable returns S with the *Table field set to NewTable(name) and every Col field bound to it,
+// the column name is taken from the db tag
+//
+//	type Users struct {
+//		*builder.Table
+//		ID   builder.Col[int]    `db:"id"`
+//		Name builder.Col[string] `db:"name"`
+//	}
+//
+//	users := builder.DefineTable[Users]("users")
+func DefineTable[S any](name string) (*S, error) {
+	var (
+		s     = new(S)
+		rv    = reflect.ValueOf(s).Elem()
+		table = NewTable(name)
+	)
+
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%s is not a struct", rv.Type())
+	}
+
+	found := false
+
+	for i := 0; i < rv.NumField(); i++ {
+		f := rv.Type().Field(i)
+
+		if !f.IsExported() {
+			continue
+		}
+
+		switch {
+		case f.Type == tableType:
+			rv.Field(i).Set(reflect.ValueOf(table))
+			found = true
+		case f.Type.Implements(typedColType):
+			if f.Type.Kind() != reflect.Struct {
+				return nil, fmt.Errorf("column %s has type %s, expected Col", f.Name, f.Type)
+			}
+
+			column, _, _ := strings.Cut(f.Tag.Get("db"), ",")
+			if column == "" {
+				return nil, fmt.Errorf("column %s has no db tag", f.Name)
+			}
+
+			rv.Field(i).FieldByName("Table").Set(reflect.ValueOf(table))
+			rv.Field(i).FieldByName("Name").SetString(column)
+		}
+	}
+
+	if !found {
+		return nil, fmt.Errorf("%s has no *Table field", rv.Type())
+	}
+
+	return s, nil
+}
@@ -0,0 +1,193 @@
+package builder
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+type testUsers struct {
+	*Table
+	ID   Col[int]    `db:"id,pk"`
+	Name Col[string] `db:"name"`
+	Age  Col[int]    `db:"age"`
+}
+
+type testOrders struct {
+	*Table
+	ID     Col[int] `db:"id"`
+	UserID Col[int] `db:"user_id"`
+}
+
+func TestDefineTable(t *testing.T) {
+	users, err := DefineTable[testUsers]("users")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if users.Table == nil || users.Table.Name != "users" {
+		t.Fatalf("table is not set")
+	}
+	if users.ID != NewCol[int](users.Table, "id") || users.Name != NewCol[string](users.Table, "name") {
+		t.Errorf("columns are not bound. return %+v %+v", users.ID, users.Name)
+	}
+
+	if _, err = DefineTable[int]("users"); err == nil {
+		t.Error("expected error")
+	}
+
+	if _, err = DefineTable[struct{ ID Col[int] }]("users"); err == nil {
+		t.Error("expected error")
+	}
+
+	if _, err = DefineTable[struct {
+		*Table
+		ID Col[int] `db:"id"`
+	}]("users"); err != nil {
+		t.Error(err)
+	}
+
+	if _, err = DefineTable[struct {
+		*Table
+		ID *Col[int] `db:"id"`
+	}]("users"); err == nil {
+		t.Error("expected error for pointer column")
+	}
+}
+
+func TestCol_where(t *testing.T) {
+	table := NewTable("users")
+	other := NewTable("other")
+	age := NewCol[int](table, "age")
+	maxAge := NewCol[int](other, "max")
+
+	tests := []struct {
+		where    Where
+		expected Where
+	}{
+		{age.Eq(1), WhereEq{Table: table, Column: "age", Value: 1}},
+		{age.NotEq(1), WhereNotEq{Table: table, Column: "age", Value: 1}},
+		{age.More(1), WhereMore{Table: table, Column: "age", Value: 1}},
+		{age.MoreEq(1), WhereMoreEq{Table: table, Column: "age", Value: 1}},
+		{age.Less(1), WhereLess{Table: table, Column: "age", Value: 1}},
+		{age.LessEq(1), WhereLessEq{Table: table, Column: "age", Value: 1}},
+		{age.In(1, 2), WhereIn{Table: table, Column: "age", Values: []int{1, 2}}},
+		{age.IsNull(), WhereIsNull{Table: table, Column: "age"}},
+		{age.IsNotNull(), WhereIsNotNull{Table: table, Column: "age"}},
+		{age.EqCol(maxAge), WhereEqColumn{Table1: table, Column1: "age", Table2: other, Column2: "max"}},
+		{age.NotEqCol(maxAge), WhereNotEqColumn{Table1: table, Column1: "age", Table2: other, Column2: "max"}},
+		{age.MoreCol(maxAge), WhereMoreColumn{Table1: table, Column1: "age", Table2: other, Column2: "max"}},
+	}
+
+	for i, tt := range tests {
+		if !reflect.DeepEqual(tt.where, tt.expected) {
+			t.Errorf("test %d: bad where. return %+v", i, tt.where)
+		}
+	}
+}
+
+func TestCol_values(t *testing.T) {
+	table := NewTable("users")
+	other := NewTable("other")
+	age := NewCol[int](table, "age")
+	maxAge := NewCol[int](other, "max")
+
+	if age.Column() != (ColumnName{Table: table, Name: "age"}) {
+		t.Errorf("bad column")
+	}
+	if age.As("a") != (ColumnName{Table: table, Name: "age", Alias: "a"}) {
+		t.Errorf("bad column alias")
+	}
+	if age.Asc() != (Order{Table: table, Column: "age"}) || age.Desc() != (Order{Table: table, Column: "age", Desc: true}) {
+		t.Errorf("bad order")
+	}
+	if age.Group() != (GroupColumn{Table: table, Column: "age"}) {
+		t.Errorf("bad group")
+	}
+	if age.OnEq(maxAge) != (OnEq{Table1: table, Column1: "age", Table2: other, Column2: "max"}) {
+		t.Errorf("bad on eq")
+	}
+	if age.OnLess(maxAge) != (OnLess{Table1: table, Column1: "age", Table2: other, Column2: "max"}) {
+		t.Errorf("bad on less")
+	}
+	if age.OnMore(maxAge) != (OnMore{Table1: table, Column1: "age", Table2: other, Column2: "max"}) {
+		t.Errorf("bad on more")
+	}
+	if age.To(5) != (Assignment{Column: "age", Value: 5}) {
+		t.Errorf("bad assignment")
+	}
+}
+
+func TestCol_queries(t *testing.T) {
+	users, err := DefineTable[testUsers]("users")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	orders, err := DefineTable[testOrders]("orders")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	q := NewSelect().Naming(NamingSequential)
+	q.From(users.Table)
+	q.Column(users.Name.Column(), ColumnCount{Table: orders.Table, Name: "id", Alias: "c"})
+	q.LeftJoin(orders.Table, orders.UserID.OnEq(users.ID))
+	q.Where(users.Age.MoreEq(18))
+	q.Group(users.Name.Group())
+	q.Order(users.Name.Asc())
+
+	sql, binds, err := q.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if sql != "SELECT t1.name, COUNT(t2.id) AS c FROM users AS t1 LEFT JOIN orders AS t2 ON t2.user_id = t1.id WHERE t1.age >= @age_1 GROUP BY t1.name ORDER BY t1.name" {
+		t.Errorf("bad returned sql. return %s", sql)
+	}
+	if !reflect.DeepEqual(binds, map[string]any{"age_1": 18}) {
+		t.Errorf("bad returned binds. return %v", binds)
+	}
+
+	u := NewUpdate(users.Table).Naming(NamingSequential)
+	u.Assign(users.Name.To("name"), users.Age.To(30))
+	u.Where(users.ID.Eq(1))
+
+	sql, binds, err = u.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if sql != "UPDATE users AS t1 SET name = @name_1, age = @age_2 WHERE t1.id = @id_3" {
+		t.Errorf("bad returned sql. return %s", sql)
+	}
+	if !reflect.DeepEqual(binds, map[string]any{"name_1": "name", "age_2": 30, "id_3": 1}) {
+		t.Errorf("bad returned binds. return %v", binds)
+	}
+
+	i := NewInsert(users.Table).Naming(NamingSequential)
+	i.Assign(users.Name.To("name"))
+
+	sql, _, err = i.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if sql != "INSERT INTO users AS t1 (name) VALUES (@name_1)" {
+		t.Errorf("bad returned sql. return %s", sql)
+	}
+}
+
+func ExampleCol() {
+	users, _ := DefineTable[testUsers]("users")
+
+	q := NewSelect().Naming(NamingSequential)
+	q.From(users.Table)
+	q.Column(users.ID.Column())
+	q.Where(users.Age.More(18)) // users.Age.More("18") does not compile
+
+	fmt.Println(q.Get())
+
+	// Output:
+	// SELECT t1.id FROM users AS t1 WHERE t1.age > @age_1 map[age_1:18] <nil>
+}
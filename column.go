@@ -30,10 +30,10 @@ func (c ColumnName) gen(q query) (string, error) {
 		s += "DISTINCT "
 	}
 
-	s += c.Table.Alias + "." + c.Name
+	s += c.Table.ref(q, c.Name)
 
 	if c.Alias != "" {
-		s += " AS " + c.Alias
+		s += " AS " + quoteIdent(c.Alias)
 	}
 
 	return s, nil
@@ -44,6 +44,7 @@ type ColumnCount struct {
 	Name     string
 	Alias    string // required
 	Distinct bool
+	Filter   Where
 }
 
 func (c ColumnCount) gen(q query) (string, error) {
@@ -58,12 +59,123 @@ func (c ColumnCount) gen(q query) (string, error) {
 			s += "DISTINCT "
 		}
 
-		s += c.Table.Alias + "." + c.Name
+		s += c.Table.ref(q, c.Name)
 	} else {
 		s += "*"
 	}
 
-	s += ") AS " + c.Alias
+	s += ")"
+
+	filter, err := genFilter(q, c.Filter)
+	if err != nil {
+		return "", err
+	}
+
+	s += filter + " AS " + quoteIdent(c.Alias)
+
+	return s, nil
+}
+
+func genFilter(q query, w Where) (string, error) {
+	if w == nil {
+		return "", nil
+	}
+
+	where, binds, err := w.gen(q)
+	if err != nil {
+		return "", err
+	}
+
+	if where == "" {
+		return "", nil
+	}
+
+	for k, v := range binds {
+		q.addBind(k, v)
+	}
+
+	return " FILTER (WHERE " + where + ")", nil
+}
+
+// ColumnAggregate renders Func over the column, e.g. SUM, ARRAY_AGG or JSONB_AGG
+type ColumnAggregate struct {
+	Func     AggregateFunc // required
+	Table    *Table        // required
+	Name     string        // required
+	Alias    string        // required
+	Distinct bool
+	Order    []Order
+	Filter   Where
+	args     []string
+}
+
+func (c ColumnAggregate) gen(q query) (string, error) {
+	if !exprFuncName.MatchString(string(c.Func)) {
+		return "", fmt.Errorf("bad aggregate func %s", c.Func)
+	}
+
+	if !q.checkTable(c.Table) {
+		return "", fmt.Errorf("table %s is not exist", c.Table)
+	}
+
+	if c.Name == "" {
+		return "", fmt.Errorf("name is empty")
+	}
+
+	if c.Alias == "" {
+		return "", fmt.Errorf("alias is empty")
+	}
+
+	s := string(c.Func) + "("
+
+	if c.Distinct {
+		s += "DISTINCT "
+	}
+
+	s += c.Table.ref(q, c.Name)
+
+	for _, arg := range c.args {
+		s += ", " + arg
+	}
+
+	if len(c.Order) != 0 {
+		order, err := genOrder(q, c.Order)
+		if err != nil {
+			return "", err
+		}
+
+		s += " ORDER BY " + order
+	}
+
+	s += ")"
+
+	filter, err := genFilter(q, c.Filter)
+	if err != nil {
+		return "", err
+	}
+
+	return s + filter + " AS " + quoteIdent(c.Alias), nil
+}
+
+type ColumnStringAgg struct {
+	Table     *Table // required
+	Name      string // required
+	Alias     string // required
+	Delimiter string
+	Distinct  bool
+	Order     []Order
+	Filter    Where
+}
+
+func (c ColumnStringAgg) gen(q query) (string, error) {
+	tag := bindTag(q, "delimiter")
+
+	s, err := ColumnAggregate{Func: AggregateStringAgg, Table: c.Table, Name: c.Name, Alias: c.Alias, Distinct: c.Distinct, Order: c.Order, Filter: c.Filter, args: []string{"@" + tag}}.gen(q)
+	if err != nil {
+		return "", err
+	}
+
+	q.addBind(tag, c.Delimiter)
 
 	return s, nil
 }
@@ -92,16 +204,9 @@ func (c ColumnCoalesce) gen(q query) (string, error) {
 		return "", fmt.Errorf("default is empty")
 	}
 
-	d := ""
-
-	switch c.Default.(type) {
-	case string:
-		d = "'" + c.Default.(string) + "'"
-	default:
-		d = fmt.Sprintf("%v", c.Default)
-	}
+	d := literalOrBind(q, c.Name, c.Default)
 
-	return "COALESCE(" + c.Table.Alias + "." + c.Name + ", " + d + ") AS " + c.Alias, nil
+	return "COALESCE(" + c.Table.ref(q, c.Name) + ", " + d + ") AS " + quoteIdent(c.Alias), nil
 }
 
 type ColumnJsonbArrayElementsText struct {
@@ -130,7 +235,7 @@ func (c ColumnJsonbArrayElementsText) gen(q query) (string, error) {
 		s += "DISTINCT "
 	}
 
-	return s + "JSONB_ARRAY_ELEMENTS_TEXT(" + c.Table.Alias + "." + c.Name + ") AS " + c.Alias, nil
+	return s + "JSONB_ARRAY_ELEMENTS_TEXT(" + c.Table.ref(q, c.Name) + ") AS " + quoteIdent(c.Alias), nil
 }
 
 type ColumnValue struct {
@@ -138,22 +243,15 @@ type ColumnValue struct {
 	Alias string
 }
 
-func (c ColumnValue) gen(_ query) (string, error) {
+func (c ColumnValue) gen(q query) (string, error) {
 	if c.Value == nil {
 		return "", fmt.Errorf("value is empty")
 	}
 
-	s := ""
-
-	switch c.Value.(type) {
-	case string:
-		s = fmt.Sprintf("'%s'", c.Value)
-	default:
-		s = fmt.Sprintf("%v", c.Value)
-	}
+	s := literalOrBind(q, c.Alias, c.Value)
 
 	if c.Alias != "" {
-		s += " AS " + c.Alias
+		s += " AS " + quoteIdent(c.Alias)
 	}
 
 	return s, nil
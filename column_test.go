@@ -79,6 +79,124 @@ func TestColumnCount_gen(t *testing.T) {
 	}
 }
 
+func TestColumnCount_Filter(t *testing.T) {
+	table := NewTable("table")
+	q := NewSelect()
+	q.From(table)
+
+	c := ColumnCount{Alias: "a1", Filter: WhereIsNull{Table: table, Column: "deleted_at"}}
+
+	s, err := c.gen(q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != "COUNT(*) FILTER (WHERE "+table.Alias+".deleted_at IS NULL) AS a1" {
+		t.Fatal(s)
+	}
+}
+
+func TestColumnAggregate_gen(t *testing.T) {
+	table1 := NewTable("table1")
+	table2 := NewTable("table2")
+	q := NewSelect()
+	q.From(table1)
+
+	tests := []struct {
+		column Column
+		fn     string
+	}{
+		{ColumnAggregate{Func: AggregateSum, Table: table1, Name: "col", Alias: "a"}, "SUM"},
+		{ColumnAggregate{Func: AggregateAvg, Table: table1, Name: "col", Alias: "a"}, "AVG"},
+		{ColumnAggregate{Func: AggregateMin, Table: table1, Name: "col", Alias: "a"}, "MIN"},
+		{ColumnAggregate{Func: AggregateMax, Table: table1, Name: "col", Alias: "a"}, "MAX"},
+		{ColumnAggregate{Func: AggregateBoolAnd, Table: table1, Name: "col", Alias: "a"}, "BOOL_AND"},
+		{ColumnAggregate{Func: AggregateBoolOr, Table: table1, Name: "col", Alias: "a"}, "BOOL_OR"},
+		{ColumnAggregate{Func: AggregateArrayAgg, Table: table1, Name: "col", Alias: "a"}, "ARRAY_AGG"},
+		{ColumnAggregate{Func: AggregateJsonbAgg, Table: table1, Name: "col", Alias: "a"}, "JSONB_AGG"},
+	}
+
+	for _, tt := range tests {
+		s, err := tt.column.gen(q)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s != tt.fn+"("+table1.Alias+".col) AS a" {
+			t.Fatal(s)
+		}
+	}
+
+	if _, err := (ColumnAggregate{Func: AggregateSum, Table: table2, Name: "col", Alias: "a"}).gen(q); err == nil {
+		t.Error("expected error")
+	}
+	if _, err := (ColumnAggregate{Func: AggregateSum, Table: table1, Alias: "a"}).gen(q); err == nil {
+		t.Error("expected error")
+	}
+	if _, err := (ColumnAggregate{Func: AggregateSum, Table: table1, Name: "col"}).gen(q); err == nil {
+		t.Error("expected error")
+	}
+	if _, err := (ColumnAggregate{Func: "SUM(1); --", Table: table1, Name: "col", Alias: "a"}).gen(q); err == nil {
+		t.Error("expected error")
+	}
+
+	c := ColumnAggregate{
+		Func:     AggregateArrayAgg,
+		Table:    table1,
+		Name:     "col",
+		Alias:    "a",
+		Distinct: true,
+		Order:    []Order{{Table: table1, Column: "col", Desc: true}},
+		Filter:   WhereEq{Table: table1, Column: "active", Value: true},
+	}
+
+	s, err := c.gen(q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(q.binds) != 1 {
+		t.Fatalf("q.binds should have 1 values")
+	}
+
+	var tag string
+
+	for k := range q.binds {
+		tag = k
+	}
+
+	if s != "ARRAY_AGG(DISTINCT "+table1.Alias+".col ORDER BY "+table1.Alias+".col DESC) FILTER (WHERE "+table1.Alias+".active = @"+tag+") AS a" {
+		t.Fatal(s)
+	}
+}
+
+func TestColumnStringAgg_gen(t *testing.T) {
+	table := NewTable("table")
+	q := NewSelect()
+	q.From(table)
+
+	c := ColumnStringAgg{Table: table, Name: "col", Alias: "a", Delimiter: ", ", Order: []Order{{Table: table, Column: "id"}}}
+
+	s, err := c.gen(q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(q.binds) != 1 {
+		t.Fatalf("q.binds should have 1 values")
+	}
+
+	var tag string
+
+	for k, v := range q.binds {
+		if v != ", " {
+			t.Errorf("delimiter should have ', '")
+		}
+
+		tag = k
+	}
+
+	if s != "STRING_AGG("+table.Alias+".col, @"+tag+" ORDER BY "+table.Alias+".id) AS a" {
+		t.Fatal(s)
+	}
+}
+
 func TestColumnCoalesce_gen(t *testing.T) {
 	table := NewTable("table")
 	q := NewSelect()
@@ -129,8 +247,14 @@ func TestColumnCoalesce_gen(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	if sql != "COALESCE("+table.Alias+".col1, 'str') AS a1" {
-		t.Fatal(sql)
+	if len(q.binds) != 1 {
+		t.Fatalf("q.binds should have 1 values")
+	}
+
+	for k, v := range q.binds {
+		if v != "str" || sql != "COALESCE("+table.Alias+".col1, @"+k+") AS a1" {
+			t.Fatal(sql)
+		}
 	}
 }
 
@@ -206,8 +330,14 @@ func TestColumnValue_gen(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	if s2 != "'1' AS a1" {
-		t.Fatal(s2)
+	if len(q.binds) != 1 {
+		t.Fatalf("q.binds should have 1 values")
+	}
+
+	for k, v := range q.binds {
+		if v != "1" || s2 != "@"+k+" AS a1" {
+			t.Fatal(s2)
+		}
 	}
 
 	_, err = c3.gen(q)
@@ -264,8 +394,8 @@ func ExampleColumnCoalesce() {
 	fmt.Println(query1.Get())
 
 	// Result:
-	// SELECT COALESCE(table1_yzethlflca.column1, 5) AS a1, COALESCE(table1_yzethlflca.column2, 'text') AS a2 FROM table1 AS table1_yzethlflca
-	// map[]
+	// SELECT COALESCE(table1_yzethlflca.column1, 5) AS a1, COALESCE(table1_yzethlflca.column2, @column2_dbadhiltlf) AS a2 FROM table1 AS table1_yzethlflca
+	// map[column2_dbadhiltlf:text]
 	// <nil>
 }
 
@@ -298,7 +428,7 @@ func ExampleColumnValue() {
 	fmt.Println(query1.Get())
 
 	// Result:
-	// SELECT 1, '1' AS a1 FROM table1 AS table1_punanojozl
-	// map[]
+	// SELECT 1, @a1_bdwhjeoxzh AS a1 FROM table1 AS table1_punanojozl
+	// map[a1_bdwhjeoxzh:1]
 	// <nil>
 }
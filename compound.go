@@ -0,0 +1,227 @@
+package builder
+
+import (
+	"fmt"
+	"maps"
+)
+
+type compoundType int
+
+const (
+	compoundUnion compoundType = iota
+	compoundUnionAll
+	compoundIntersect
+	compoundExcept
+)
+
+func (t compoundType) String() string {
+	switch t {
+	case compoundUnionAll:
+		return "UNION ALL"
+	case compoundIntersect:
+		return "INTERSECT"
+	case compoundExcept:
+		return "EXCEPT"
+	default:
+		return "UNION"
+	}
+}
+
+type compoundPart struct {
+	Query *SelectQuery
+	Type  compoundType
+}
+
+// CompoundQuery combines several SelectQuery with UNION, UNION ALL, INTERSECT and EXCEPT
+type CompoundQuery struct {
+	namer
+	scope
+	parts  []compoundPart
+	order  []Order
+	limit  int
+	offset int
+	binds  map[string]any
+}
+
+func NewCompound(q *SelectQuery) *CompoundQuery {
+	return &CompoundQuery{
+		parts: []compoundPart{{Query: q}},
+		namer: newNamer(),
+		binds: make(map[string]any),
+	}
+}
+
+func (q *CompoundQuery) checkTable(table *Table) bool {
+	return q.checkOuter(table)
+}
+
+func (q *CompoundQuery) addBind(key string, value any) {
+	q.binds[key] = value
+}
+
+func (q *CompoundQuery) names() *namer {
+	return &q.namer
+}
+
+// Naming sets the alias and bind naming of the query, see Naming
+func (q *CompoundQuery) Naming(n Naming) *CompoundQuery {
+	q.namer.mode = n
+
+	return q
+}
+
+func (q *CompoundQuery) add(t compoundType, s ...*SelectQuery) *CompoundQuery {
+	for _, sq := range s {
+		q.parts = append(q.parts, compoundPart{Query: sq, Type: t})
+	}
+
+	return q
+}
+
+func (q *CompoundQuery) Union(s ...*SelectQuery) *CompoundQuery {
+	return q.add(compoundUnion, s...)
+}
+
+func (q *CompoundQuery) UnionAll(s ...*SelectQuery) *CompoundQuery {
+	return q.add(compoundUnionAll, s...)
+}
+
+func (q *CompoundQuery) Intersect(s ...*SelectQuery) *CompoundQuery {
+	return q.add(compoundIntersect, s...)
+}
+
+func (q *CompoundQuery) Except(s ...*SelectQuery) *CompoundQuery {
+	return q.add(compoundExcept, s...)
+}
+
+// Order sorts the combined result, only columns of the result without Table are allowed
+func (q *CompoundQuery) Order(o ...Order) *CompoundQuery {
+	q.order = append(q.order, o...)
+
+	return q
+}
+
+func (q *CompoundQuery) Limit(limit int) *CompoundQuery {
+	if limit <= 0 {
+		return q
+	}
+
+	q.limit = limit
+
+	return q
+}
+
+func (q *CompoundQuery) Offset(offset int) *CompoundQuery {
+	if offset <= 0 {
+		return q
+	}
+
+	q.offset = offset
+
+	return q
+}
+
+func (q *CompoundQuery) getParts() (string, error) {
+	if len(q.parts) < 2 {
+		return "", fmt.Errorf("compound query needs at least 2 queries")
+	}
+
+	s := ""
+
+	for i, p := range q.parts {
+		if p.Query == nil {
+			return "", fmt.Errorf("query cannot be nil")
+		}
+
+		sql, binds, err := getDerived(q, p.Query)
+		if err != nil {
+			return "", err
+		}
+
+		for k, v := range binds {
+			q.addBind(k, v)
+		}
+
+		if i != 0 {
+			s += " " + p.Type.String() + " "
+		}
+
+		s += "(" + sql + ")"
+	}
+
+	return s, nil
+}
+
+func (q *CompoundQuery) getOrder() (string, error) {
+	if len(q.order) == 0 {
+		return "", nil
+	}
+
+	for _, o := range q.order {
+		if o.Table != nil {
+			return "", fmt.Errorf("order of compound query cannot use table %s", o.Table.Name)
+		}
+	}
+
+	s, err := genOrder(q, q.order)
+	if err != nil {
+		return "", err
+	}
+
+	return " ORDER BY " + s, nil
+}
+
+// GetPositional returns SQL with $1..$n placeholders and ordered arguments for database/sql, lib/pq and pgx
+func (q *CompoundQuery) GetPositional() (string, []any, error) {
+	return getPositional(q)
+}
+
+func (q *CompoundQuery) Get() (string, map[string]any, error) {
+	return q.render(nil, nil)
+}
+
+// render builds a copy of the query, so Get() leaves the query untouched
+func (q *CompoundQuery) render(root *namer, outer query) (string, map[string]any, error) {
+	return q.namer.get(root, func(root *namer) (string, map[string]any, error) {
+		c := q.clone()
+		c.namer.root = root
+		c.scope.parent = outer
+
+		return c.get()
+	})
+}
+
+func (q *CompoundQuery) clone() *CompoundQuery {
+	c := *q
+	c.binds = maps.Clone(q.binds)
+
+	return &c
+}
+
+func (q *CompoundQuery) get() (string, map[string]any, error) {
+	parts, err := q.getParts()
+	if err != nil {
+		return "", nil, err
+	}
+
+	order, err := q.getOrder()
+	if err != nil {
+		return "", nil, err
+	}
+
+	limit := ""
+	if q.limit > 0 {
+		tag := bindTag(q, "limit")
+		q.addBind(tag, q.limit)
+		limit = " LIMIT @" + tag
+	}
+
+	offset := ""
+	if q.offset > 0 {
+		tag := bindTag(q, "offset")
+		q.addBind(tag, q.offset)
+		offset = " OFFSET @" + tag
+	}
+
+	return parts + order + limit + offset, q.binds, nil
+}
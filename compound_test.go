@@ -0,0 +1,163 @@
+package builder
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestCompoundQuery_add(t *testing.T) {
+	q1, q2, q3, q4, q5 := NewSelect(), NewSelect(), NewSelect(), NewSelect(), NewSelect()
+
+	q := NewCompound(q1)
+	q.Union(q2)
+	q.UnionAll(q3)
+	q.Intersect(q4)
+	q.Except(q5)
+
+	if len(q.parts) != 5 {
+		t.Fatalf("q.parts should have 5 values")
+	}
+
+	types := []compoundType{compoundUnion, compoundUnion, compoundUnionAll, compoundIntersect, compoundExcept}
+
+	for i, p := range q.parts {
+		if p.Type != types[i] {
+			t.Errorf("q.parts[%d].Type should have %s", i, types[i])
+		}
+	}
+}
+
+func TestCompoundQuery_getOrder(t *testing.T) {
+	q := NewCompound(NewSelect())
+
+	order, err := q.getOrder()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if order != "" {
+		t.Errorf("order should have empty string")
+	}
+
+	q.Order(Order{Column: "col1"}, Order{Column: "col2", Desc: true})
+
+	order, err = q.getOrder()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if order != " ORDER BY col1, col2 DESC" {
+		t.Errorf("bad returned order. return %s", order)
+	}
+
+	q.Order(Order{Table: NewTable("table"), Column: "col3"})
+
+	if _, err = q.getOrder(); err == nil {
+		t.Errorf("q.getOrder should have error")
+	}
+}
+
+func TestCompoundQuery_Get(t *testing.T) {
+	table1 := NewTable("table1")
+	table2 := NewTable("table2")
+
+	q1 := NewSelect()
+	q1.From(table1)
+	q1.Column(ColumnName{Table: table1, Name: "id"})
+	q1.Where(WhereEq{Table: table1, Column: "col", Value: 1})
+
+	q2 := NewSelect()
+	q2.From(table2)
+	q2.Column(ColumnName{Table: table2, Name: "id"})
+
+	q := NewCompound(q1)
+
+	if _, _, err := q.Get(); err == nil {
+		t.Errorf("q.Get should have error")
+	}
+
+	q.UnionAll(q2)
+	q.Order(Order{Column: "id"})
+	q.Limit(10)
+	q.Offset(5)
+
+	sql, binds, err := q.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(binds) != 3 {
+		t.Fatalf("binds should have 3 values")
+	}
+
+	var w, l, o string
+
+	for k, v := range binds {
+		switch v {
+		case 1:
+			w = k
+		case 10:
+			l = k
+		case 5:
+			o = k
+		}
+	}
+
+	st := fmt.Sprintf("(SELECT %[1]s.id FROM table1 AS %[1]s WHERE %[1]s.col = @%[3]s) UNION ALL (SELECT %[2]s.id FROM table2 AS %[2]s) ORDER BY id LIMIT @%[4]s OFFSET @%[5]s", table1.Alias, table2.Alias, w, l, o)
+	if sql != st {
+		t.Errorf("bad returned sql. return:\n'%s'\n'%s'", sql, st)
+	}
+}
+
+func TestCompoundQuery_Sub(t *testing.T) {
+	table1 := NewTable("table1")
+	table2 := NewTable("table2")
+
+	q1 := NewSelect()
+	q1.From(table1)
+	q1.Column(ColumnName{Table: table1, Name: "id"})
+
+	q2 := NewSelect()
+	q2.From(table2)
+	q2.Column(ColumnName{Table: table2, Name: "id"})
+
+	sub := NewTableSub(NewCompound(q1).Except(q2))
+
+	q := NewSelect()
+	q.From(sub)
+	q.Column(ColumnName{Table: sub, Name: "id"})
+
+	sql, _, err := q.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	st := fmt.Sprintf("SELECT %[3]s.id FROM ((SELECT %[1]s.id FROM table1 AS %[1]s) EXCEPT (SELECT %[2]s.id FROM table2 AS %[2]s)) AS %[3]s", table1.Alias, table2.Alias, sub.Alias)
+	if sql != st {
+		t.Errorf("bad returned sql. return:\n'%s'\n'%s'", sql, st)
+	}
+}
+
+func TestCompoundQuery_Exists(t *testing.T) {
+	table1 := NewTable("table1")
+	table2 := NewTable("table2")
+	table3 := NewTable("table3")
+
+	q1 := NewSelect()
+	q1.From(table1)
+	q1.Column(ColumnValue{Value: 1})
+
+	q2 := NewSelect()
+	q2.From(table2)
+	q2.Column(ColumnValue{Value: 1})
+
+	q := NewSelect()
+	q.From(table3)
+
+	sql, _, err := WhereExists{Query: NewCompound(q1).Intersect(q2)}.gen(q)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	st := fmt.Sprintf("EXISTS((SELECT 1 FROM table1 AS %[1]s) INTERSECT (SELECT 1 FROM table2 AS %[2]s))", table1.Alias, table2.Alias)
+	if sql != st {
+		t.Errorf("bad returned sql. return:\n'%s'\n'%s'", sql, st)
+	}
+}
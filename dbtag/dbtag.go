@@ -0,0 +1,174 @@
+// Package dbtag maps struct fields to columns by the db tag, it is shared by the builder and pgxexec
+package dbtag
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Field is a struct field mapped to a column by the db tag, `db:"name,omitempty,readonly,pk"`
+type Field struct {
+	Column    string
+	Index     []int
+	OmitEmpty bool
+	ReadOnly  bool
+	PK        bool
+}
+
+var cache sync.Map
+
+// Fields returns the tagged fields of t, fields of embedded structs without a tag are included.
+// As in encoding/json a shallower field hides a deeper one with the same column,
+// fields with the same column at the same depth hide each other.
+func Fields(t reflect.Type) []Field {
+	if f, ok := cache.Load(t); ok {
+		return f.([]Field)
+	}
+
+	list := typeFields(t)
+
+	cache.Store(t, list)
+
+	return list
+}
+
+type embedded struct {
+	typ   reflect.Type
+	index []int
+}
+
+// typeFields walks embedded structs breadth first, a struct type is walked at its shallowest depth only,
+// which also stops self-referential embedding
+func typeFields(t reflect.Type) []Field {
+	var (
+		next    = []embedded{{typ: t}}
+		visited = make(map[reflect.Type]bool)
+		hidden  = make(map[string]bool)
+		list    []Field
+	)
+
+	for len(next) != 0 {
+		var (
+			current = next
+			found   []Field
+			count   = make(map[string]int)
+		)
+
+		next = nil
+
+		for _, e := range current {
+			if visited[e.typ] {
+				continue
+			}
+
+			for i := 0; i < e.typ.NumField(); i++ {
+				f := e.typ.Field(i)
+				index := append(e.index[:len(e.index):len(e.index)], i)
+
+				tag, ok := f.Tag.Lookup("db")
+				if tag == "-" {
+					continue
+				}
+
+				if !ok {
+					ft := f.Type
+					if ft.Kind() == reflect.Pointer {
+						ft = ft.Elem()
+					}
+
+					if f.Anonymous && ft.Kind() == reflect.Struct {
+						next = append(next, embedded{typ: ft, index: index})
+					}
+
+					continue
+				}
+
+				if !f.IsExported() {
+					continue
+				}
+
+				sf := parseField(tag, f.Name, index)
+				if hidden[sf.Column] {
+					continue
+				}
+
+				count[sf.Column]++
+				found = append(found, sf)
+			}
+		}
+
+		for _, e := range current {
+			visited[e.typ] = true
+		}
+
+		for _, f := range found {
+			if count[f.Column] == 1 {
+				list = append(list, f)
+			}
+
+			hidden[f.Column] = true
+		}
+	}
+
+	slices.SortFunc(list, func(a, b Field) int {
+		return slices.Compare(a.Index, b.Index)
+	})
+
+	return list
+}
+
+func parseField(tag, name string, index []int) Field {
+	parts := strings.Split(tag, ",")
+
+	f := Field{Column: parts[0], Index: index}
+	if f.Column == "" {
+		f.Column = name
+	}
+
+	for _, o := range parts[1:] {
+		switch strings.TrimSpace(o) {
+		case "omitempty":
+			f.OmitEmpty = true
+		case "readonly":
+			f.ReadOnly = true
+		case "pk":
+			f.PK = true
+		}
+	}
+
+	return f
+}
+
+// Value returns the field of rv, ok is false when an embedded pointer on the path is nil
+func (f Field) Value(rv reflect.Value) (reflect.Value, bool) {
+	v, err := rv.FieldByIndexErr(f.Index)
+	if err != nil {
+		return reflect.Value{}, false
+	}
+
+	return v, true
+}
+
+// Addr returns a pointer to the field of the addressable rv, nil embedded pointers on the path are allocated.
+// ok is false when a nil embedded pointer cannot be set, e.g. of an unexported type.
+func (f Field) Addr(rv reflect.Value) (any, bool) {
+	for i, x := range f.Index {
+		if i > 0 && rv.Kind() == reflect.Pointer {
+			if rv.IsNil() {
+				if !rv.CanSet() {
+					return nil, false
+				}
+
+				rv.Set(reflect.New(rv.Type().Elem()))
+			}
+
+			rv = rv.Elem()
+		}
+
+		rv = rv.Field(x)
+	}
+
+	return rv.Addr().Interface(), true
+}
@@ -0,0 +1,134 @@
+package dbtag
+
+import (
+	"reflect"
+	"testing"
+)
+
+type testTimestamps struct {
+	CreatedAt string `db:"created_at,readonly"`
+	UpdatedAt string `db:"updated_at,omitempty"`
+}
+
+type testUser struct {
+	ID      int    `db:"id,pk,omitempty"`
+	Name    string `db:"name"`
+	Email   string `db:",omitempty"`
+	Note    string `db:"-"`
+	Skip    string
+	private string `db:"private"`
+	*testTimestamps
+}
+
+func TestFields(t *testing.T) {
+	fields := Fields(reflect.TypeOf(testUser{}))
+
+	expected := []Field{
+		{Column: "id", Index: []int{0}, PK: true, OmitEmpty: true},
+		{Column: "name", Index: []int{1}},
+		{Column: "Email", Index: []int{2}, OmitEmpty: true},
+		{Column: "created_at", Index: []int{6, 0}, ReadOnly: true},
+		{Column: "updated_at", Index: []int{6, 1}, OmitEmpty: true},
+	}
+
+	if !reflect.DeepEqual(fields, expected) {
+		t.Errorf("bad fields. return %+v", fields)
+	}
+}
+
+type testNode struct {
+	*testNode
+	ID int `db:"id"`
+}
+
+type testBase struct {
+	ID   int    `db:"id"`
+	Name string `db:"name"`
+}
+
+type testLeft struct {
+	Tag string `db:"tag"`
+}
+
+type testRight struct {
+	Tag string `db:"tag"`
+}
+
+func TestFields_embedded(t *testing.T) {
+	fields := Fields(reflect.TypeOf(testNode{}))
+
+	if !reflect.DeepEqual(fields, []Field{{Column: "id", Index: []int{1}}}) {
+		t.Errorf("bad fields of self-referential struct. return %+v", fields)
+	}
+
+	type shadow struct {
+		testBase
+		Name string `db:"name"`
+		testLeft
+		testRight
+	}
+
+	fields = Fields(reflect.TypeOf(shadow{}))
+
+	expected := []Field{
+		{Column: "id", Index: []int{0, 0}},
+		{Column: "name", Index: []int{1}},
+	}
+
+	if !reflect.DeepEqual(fields, expected) {
+		t.Errorf("bad fields of shadowed struct. return %+v", fields)
+	}
+}
+
+func TestField_Value(t *testing.T) {
+	fields := Fields(reflect.TypeOf(testUser{}))
+	rv := reflect.ValueOf(testUser{Name: "name"})
+
+	v, ok := fields[1].Value(rv)
+	if !ok || v.Interface() != "name" {
+		t.Errorf("bad value. return %v %v", v, ok)
+	}
+
+	if _, ok = fields[3].Value(rv); ok {
+		t.Errorf("value of nil embedded struct should not be ok")
+	}
+}
+
+type TestTimestamps struct {
+	CreatedAt string `db:"created_at"`
+}
+
+func TestField_Addr(t *testing.T) {
+	type user struct {
+		Name string `db:"name"`
+		*TestTimestamps
+	}
+
+	fields := Fields(reflect.TypeOf(user{}))
+
+	var u user
+
+	rv := reflect.ValueOf(&u).Elem()
+
+	for i, v := range []string{"name", "now"} {
+		p, ok := fields[i].Addr(rv)
+		if !ok {
+			t.Fatalf("field %s should be addressable", fields[i].Column)
+		}
+
+		*p.(*string) = v
+	}
+
+	if u.Name != "name" {
+		t.Errorf("name is not set")
+	}
+	if u.TestTimestamps == nil || u.CreatedAt != "now" {
+		t.Errorf("embedded field is not set")
+	}
+
+	var u2 testUser
+
+	if _, ok := Fields(reflect.TypeOf(u2))[3].Addr(reflect.ValueOf(&u2).Elem()); ok {
+		t.Errorf("field of unexported embedded pointer should not be addressable")
+	}
+}
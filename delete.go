@@ -1,29 +1,60 @@
 package builder
 
-import "fmt"
+import (
+	"fmt"
+	"maps"
+)
 
 type DeleteQuery struct {
-	table *Table
-	where Where
-	full  bool
-	binds map[string]any
+	with
+	namer
+	scope
+	table   *Table
+	using   []*Table
+	where   Where
+	full    bool
+	returns []Column
+	binds   map[string]any
+	err     error
 }
 
 func NewDelete(table *Table) *DeleteQuery {
 	return &DeleteQuery{
 		table: table,
+		namer: newNamer(),
 		binds: make(map[string]any),
 	}
 }
 
 func (q *DeleteQuery) checkTable(table *Table) bool {
-	return q.table == table
+	if q.table == table {
+		return true
+	}
+
+	for _, t := range q.using {
+		if t == table {
+			return true
+		}
+	}
+
+	return q.checkOuter(table)
 }
 
 func (q *DeleteQuery) addBind(key string, value any) {
 	q.binds[key] = value
 }
 
+func (q *DeleteQuery) names() *namer {
+	return &q.namer
+}
+
+// Naming sets the alias and bind naming of the query, see Naming
+func (q *DeleteQuery) Naming(n Naming) *DeleteQuery {
+	q.namer.mode = n
+
+	return q
+}
+
 func (q *DeleteQuery) Where(w Where) *DeleteQuery {
 	q.where = w
 
@@ -36,6 +67,46 @@ func (q *DeleteQuery) Full() *DeleteQuery {
 	return q
 }
 
+// Using adds tables for DELETE ... USING, they can be referenced in Where and Return
+func (q *DeleteQuery) Using(t ...*Table) *DeleteQuery {
+	q.using = append(q.using, t...)
+
+	return q
+}
+
+func (q *DeleteQuery) Return(c ...Column) *DeleteQuery {
+	q.returns = append(q.returns, c...)
+
+	return q
+}
+
+func (q *DeleteQuery) getUsing() (string, error) {
+	if len(q.using) == 0 {
+		return "", nil
+	}
+
+	s := " USING "
+
+	for i, using := range q.using {
+		sql, binds, err := using.gen(q)
+		if err != nil {
+			return "", err
+		}
+
+		for k, v := range binds {
+			q.addBind(k, v)
+		}
+
+		s += sql
+
+		if i != len(q.using)-1 {
+			s += ", "
+		}
+	}
+
+	return s, nil
+}
+
 func (q *DeleteQuery) getWhere() (string, error) {
 	if q.where == nil {
 		return "", nil
@@ -57,11 +128,80 @@ func (q *DeleteQuery) getWhere() (string, error) {
 	return " WHERE " + where, nil
 }
 
+func (q *DeleteQuery) getReturns() (string, error) {
+	if len(q.returns) == 0 {
+		return "", nil
+	}
+
+	var s string
+
+	for i, v := range q.returns {
+		c, err := v.gen(q)
+		if err != nil {
+			return "", err
+		}
+
+		s += c
+
+		if i != len(q.returns)-1 {
+			s += ", "
+		}
+	}
+
+	return " RETURNING " + s, nil
+}
+
+// GetPositional returns SQL with $1..$n placeholders and ordered arguments for database/sql, lib/pq and pgx
+func (q *DeleteQuery) GetPositional() (string, []any, error) {
+	return getPositional(q)
+}
+
 func (q *DeleteQuery) Get() (string, map[string]any, error) {
+	return q.render(nil, nil)
+}
+
+// render builds a copy of the query, so Get() leaves the query untouched
+func (q *DeleteQuery) render(root *namer, outer query) (string, map[string]any, error) {
+	return q.namer.get(root, func(root *namer) (string, map[string]any, error) {
+		c := q.clone()
+		c.namer.root = root
+		c.scope.parent = outer
+
+		return c.get()
+	})
+}
+
+func (q *DeleteQuery) clone() *DeleteQuery {
+	c := *q
+	c.binds = maps.Clone(q.binds)
+
+	return &c
+}
+
+func (q *DeleteQuery) get() (string, map[string]any, error) {
+	if q.err != nil {
+		return "", nil, q.err
+	}
+
 	if q.table == nil {
 		return "", nil, fmt.Errorf("table not set")
 	}
 
+	table, err := q.table.target(q)
+	if err != nil {
+		return "", nil, err
+	}
+
+	with, err := q.getWith(q)
+	if err != nil {
+		return "", nil, err
+	}
+
+	using, err := q.getUsing()
+	if err != nil {
+		return "", nil, err
+	}
+
 	where, err := q.getWhere()
 	if err != nil {
 		return "", nil, err
@@ -71,5 +211,10 @@ func (q *DeleteQuery) Get() (string, map[string]any, error) {
 		return "", nil, fmt.Errorf("use .Full() to delete without WHERE")
 	}
 
-	return "DELETE FROM " + q.table.Name + " AS " + q.table.Alias + where, q.binds, nil
+	returns, err := q.getReturns()
+	if err != nil {
+		return "", nil, err
+	}
+
+	return with + "DELETE FROM " + table + using + where + returns, q.binds, nil
 }
@@ -1,6 +1,7 @@
 package builder
 
 import (
+	"fmt"
 	"testing"
 )
 
@@ -72,6 +73,54 @@ func TestDeleteQuery_Full(t *testing.T) {
 	}
 }
 
+func TestDeleteQuery_Using(t *testing.T) {
+	table1 := NewTable("table1")
+	table2 := NewTable("table2")
+
+	q := NewDelete(table1)
+
+	if q.checkTable(table2) {
+		t.Errorf("q.checkTable() returned true")
+	}
+
+	q.Using(table2)
+
+	if !q.checkTable(table2) {
+		t.Errorf("q.checkTable() returned false")
+	}
+
+	q.Where(WhereAnd{List: []Where{
+		WhereEqColumn{Table1: table1, Column1: "table2_id", Table2: table2, Column2: "id"},
+		WhereEq{Table: table2, Column: "col", Value: 5},
+	}})
+	q.Return(ColumnName{Table: table1, Name: "id"}, ColumnName{Table: table2, Name: "name", Alias: "a1"})
+
+	sql, binds, err := q.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(binds) != 1 {
+		t.Fatalf("binds should have 1 values")
+	}
+
+	var tag string
+
+	for k := range binds {
+		tag = k
+	}
+
+	st := fmt.Sprintf("DELETE FROM table1 AS %[1]s USING table2 AS %[2]s WHERE (%[1]s.table2_id = %[2]s.id AND %[2]s.col = @%[3]s) RETURNING %[1]s.id, %[2]s.name AS a1", table1.Alias, table2.Alias, tag)
+	if sql != st {
+		t.Errorf("bad returned sql. return:\n'%s'\n'%s'", sql, st)
+	}
+
+	q.Return(ColumnName{Table: NewTable("table3"), Name: "id"})
+
+	if _, _, err = q.Get(); err == nil {
+		t.Error("expected error")
+	}
+}
+
 func TestDeleteQuery_getWhere(t *testing.T) {
 	table := NewTable("table")
 	q := NewDelete(table)
@@ -156,13 +205,13 @@ func TestDeleteQuery_Get(t *testing.T) {
 
 	var tag string
 
-	for k, v := range q.binds {
+	for k, v := range binds {
 		if v == 5 {
 			tag = k
 		}
 	}
 
-	if sql != "DELETE FROM "+table.Name+" AS "+table.Alias+" WHERE "+table.Alias+".col = @"+tag {
+	if sql != "DELETE FROM \"table\" AS "+table.Alias+" WHERE "+table.Alias+".col = @"+tag {
 		t.Errorf("bad returned where. return %s", sql)
 	}
 
@@ -178,7 +227,7 @@ func TestDeleteQuery_Get(t *testing.T) {
 		t.Errorf("binds should have 0 values")
 	}
 
-	if sql != "DELETE FROM "+table.Name+" AS "+table.Alias {
+	if sql != "DELETE FROM \"table\" AS "+table.Alias {
 		t.Errorf("bad returned where. return %s", sql)
 	}
 }
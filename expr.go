@@ -0,0 +1,248 @@
+package builder
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Expr is an SQL expression usable as a value of UpdateQuery.Set and InsertQuery.Value/UpdateSet
+type Expr interface {
+	gen(q query) (string, map[string]any, error)
+}
+
+type ExprValue struct {
+	Value any
+}
+
+func (e ExprValue) gen(q query) (string, map[string]any, error) {
+	if q == nil {
+		return "", nil, fmt.Errorf("query cannot be nil")
+	}
+
+	tag := bindTag(q, "value")
+
+	return "@" + tag, map[string]any{tag: e.Value}, nil
+}
+
+type ExprColumn struct {
+	Table  *Table // required
+	Column string // required
+}
+
+func (e ExprColumn) gen(q query) (string, map[string]any, error) {
+	if q == nil {
+		return "", nil, fmt.Errorf("query cannot be nil")
+	}
+
+	if !q.checkTable(e.Table) {
+		return "", nil, fmt.Errorf("table %s does not exist", e.Table)
+	}
+
+	if e.Column == "" {
+		return "", nil, fmt.Errorf("column is empty")
+	}
+
+	return e.Table.ref(q, e.Column), nil, nil
+}
+
+// ExprOp is a binary operation, Op is one of + - * / % ||
+type ExprOp struct {
+	Left  Expr   // required
+	Op    string // required
+	Right Expr   // required
+}
+
+func (e ExprOp) gen(q query) (string, map[string]any, error) {
+	switch e.Op {
+	case "+", "-", "*", "/", "%", "||":
+	default:
+		return "", nil, fmt.Errorf("bad operator %s", e.Op)
+	}
+
+	list, binds, err := genExprList(q, []Expr{e.Left, e.Right})
+	if err != nil {
+		return "", nil, err
+	}
+
+	return "(" + list[0] + " " + e.Op + " " + list[1] + ")", binds, nil
+}
+
+var exprFuncName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.]*$`)
+
+type ExprFunc struct {
+	Name string // required
+	Args []Expr
+}
+
+func (e ExprFunc) gen(q query) (string, map[string]any, error) {
+	if !exprFuncName.MatchString(e.Name) {
+		return "", nil, fmt.Errorf("bad function name %s", e.Name)
+	}
+
+	list, binds, err := genExprList(q, e.Args)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return e.Name + "(" + strings.Join(list, ", ") + ")", binds, nil
+}
+
+type ExprCoalesce struct {
+	List []Expr // required
+}
+
+func (e ExprCoalesce) gen(q query) (string, map[string]any, error) {
+	if len(e.List) == 0 {
+		return "", nil, fmt.Errorf("coalesce is empty")
+	}
+
+	list, binds, err := genExprList(q, e.List)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return "COALESCE(" + strings.Join(list, ", ") + ")", binds, nil
+}
+
+type ExprWhen struct {
+	Cond Where // required
+	Then Expr  // required
+}
+
+type ExprCase struct {
+	When []ExprWhen // required
+	Else Expr
+}
+
+func (e ExprCase) gen(q query) (string, map[string]any, error) {
+	if q == nil {
+		return "", nil, fmt.Errorf("query cannot be nil")
+	}
+
+	if len(e.When) == 0 {
+		return "", nil, fmt.Errorf("case has no when")
+	}
+
+	var (
+		s     = "CASE"
+		binds = make(map[string]any)
+	)
+
+	for _, w := range e.When {
+		if w.Cond == nil || w.Then == nil {
+			return "", nil, fmt.Errorf("when is empty")
+		}
+
+		cond, b, err := w.Cond.gen(q)
+		if err != nil {
+			return "", nil, err
+		}
+
+		for k, v := range b {
+			binds[k] = v
+		}
+
+		then, b, err := w.Then.gen(q)
+		if err != nil {
+			return "", nil, err
+		}
+
+		for k, v := range b {
+			binds[k] = v
+		}
+
+		s += " WHEN " + cond + " THEN " + then
+	}
+
+	if e.Else != nil {
+		el, b, err := e.Else.gen(q)
+		if err != nil {
+			return "", nil, err
+		}
+
+		for k, v := range b {
+			binds[k] = v
+		}
+
+		s += " ELSE " + el
+	}
+
+	return s + " END", binds, nil
+}
+
+type ExprDefault struct{}
+
+func (e ExprDefault) gen(_ query) (string, map[string]any, error) {
+	return "DEFAULT", nil, nil
+}
+
+// ExprSub is a scalar subquery
+type ExprSub struct {
+	Query *SelectQuery // required
+}
+
+func (e ExprSub) gen(q query) (string, map[string]any, error) {
+	if q == nil {
+		return "", nil, fmt.Errorf("query cannot be nil")
+	}
+
+	if e.Query == nil {
+		return "", nil, fmt.Errorf("subquery cannot be nil")
+	}
+
+	sql, binds, err := getSub(q, e.Query)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return "(" + sql + ")", binds, nil
+}
+
+func genExprList(q query, list []Expr) ([]string, map[string]any, error) {
+	var (
+		s     = make([]string, len(list))
+		binds = make(map[string]any)
+	)
+
+	for i, e := range list {
+		if e == nil {
+			return nil, nil, fmt.Errorf("expression cannot be nil")
+		}
+
+		sql, b, err := e.gen(q)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for k, v := range b {
+			binds[k] = v
+		}
+
+		s[i] = sql
+	}
+
+	return s, binds, nil
+}
+
+// genValue renders v for column as Expr or as a bound value
+func genValue(q query, column string, v any) (string, error) {
+	if e, ok := v.(Expr); ok {
+		sql, binds, err := e.gen(q)
+		if err != nil {
+			return "", err
+		}
+
+		for k, v := range binds {
+			q.addBind(k, v)
+		}
+
+		return sql, nil
+	}
+
+	tag := bindTag(q, column)
+
+	q.addBind(tag, v)
+
+	return "@" + tag, nil
+}
@@ -0,0 +1,176 @@
+package builder
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestExpr_gen(t *testing.T) {
+	table1 := NewTable("table1")
+	table2 := NewTable("table2")
+
+	q := NewUpdate(table1)
+
+	sql, binds, err := ExprValue{Value: 5}.gen(q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(binds) != 1 {
+		t.Fatalf("binds should have 1 values")
+	}
+
+	for k, v := range binds {
+		if v != 5 || sql != "@"+k {
+			t.Errorf("bad returned expr. return %s", sql)
+		}
+	}
+
+	sql, _, err = ExprColumn{Table: table1, Column: "col"}.gen(q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sql != table1.Alias+".col" {
+		t.Errorf("bad returned expr. return %s", sql)
+	}
+
+	if _, _, err = (ExprColumn{Table: table2, Column: "col"}).gen(q); err == nil {
+		t.Error("expected error")
+	}
+
+	sql, _, err = ExprOp{Left: ExprColumn{Table: table1, Column: "count"}, Op: "+", Right: ExprDefault{}}.gen(q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sql != "("+table1.Alias+".count + DEFAULT)" {
+		t.Errorf("bad returned expr. return %s", sql)
+	}
+
+	if _, _, err = (ExprOp{Left: ExprDefault{}, Op: "; --", Right: ExprDefault{}}).gen(q); err == nil {
+		t.Error("expected error")
+	}
+	if _, _, err = (ExprOp{Left: ExprDefault{}, Op: "+"}).gen(q); err == nil {
+		t.Error("expected error")
+	}
+
+	sql, _, err = ExprFunc{Name: "LOWER", Args: []Expr{ExprColumn{Table: table1, Column: "name"}}}.gen(q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sql != "LOWER("+table1.Alias+".name)" {
+		t.Errorf("bad returned expr. return %s", sql)
+	}
+
+	if _, _, err = (ExprFunc{Name: "NOW(); DROP TABLE x; --"}).gen(q); err == nil {
+		t.Error("expected error")
+	}
+
+	sql, _, err = ExprCoalesce{List: []Expr{ExprColumn{Table: table1, Column: "a"}, ExprColumn{Table: table1, Column: "b"}}}.gen(q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sql != "COALESCE("+table1.Alias+".a, "+table1.Alias+".b)" {
+		t.Errorf("bad returned expr. return %s", sql)
+	}
+
+	if _, _, err = (ExprCoalesce{}).gen(q); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestExprCase_gen(t *testing.T) {
+	table := NewTable("table")
+
+	q := NewUpdate(table)
+
+	e := ExprCase{
+		When: []ExprWhen{{Cond: WhereIsNull{Table: table, Column: "col"}, Then: ExprValue{Value: 1}}},
+		Else: ExprColumn{Table: table, Column: "col"},
+	}
+
+	sql, binds, err := e.gen(q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(binds) != 1 {
+		t.Fatalf("binds should have 1 values")
+	}
+
+	var tag string
+
+	for k := range binds {
+		tag = k
+	}
+
+	if sql != fmt.Sprintf("CASE WHEN %[1]s.col IS NULL THEN @%[2]s ELSE %[1]s.col END", table.Alias, tag) {
+		t.Errorf("bad returned expr. return %s", sql)
+	}
+
+	if _, _, err = (ExprCase{}).gen(q); err == nil {
+		t.Error("expected error")
+	}
+	if _, _, err = (ExprCase{When: []ExprWhen{{}}}).gen(q); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestExprSub_gen(t *testing.T) {
+	table1 := NewTable("table1")
+	table2 := NewTable("table2")
+
+	sub := NewSelect()
+	sub.From(table2)
+	sub.Column(ColumnCount{Alias: "c"})
+	sub.Where(WhereEq{Table: table2, Column: "col", Value: 1})
+
+	q := NewUpdate(table1)
+	q.Set("total", ExprSub{Query: sub})
+	q.Set("count", ExprOp{Left: ExprColumn{Table: table1, Column: "count"}, Op: "+", Right: ExprValue{Value: 3}})
+	q.Set("name", ExprDefault{})
+
+	sql, binds, err := q.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(binds) != 2 {
+		t.Fatalf("binds should have 2 values")
+	}
+
+	var tag1, tag2 string
+
+	for k, v := range binds {
+		if v == 1 {
+			tag1 = k
+		} else if v == 3 {
+			tag2 = k
+		}
+	}
+
+	st := fmt.Sprintf("UPDATE table1 AS %[1]s SET total = (SELECT COUNT(*) AS c FROM table2 AS %[2]s WHERE %[2]s.col = @%[3]s), count = (%[1]s.count + @%[4]s), name = DEFAULT", table1.Alias, table2.Alias, tag1, tag2)
+	if sql != st {
+		t.Errorf("bad returned sql. return:\n'%s'\n'%s'", sql, st)
+	}
+
+	if _, _, err = (ExprSub{}).gen(q); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestExpr_Insert(t *testing.T) {
+	table := NewTable("table")
+
+	q := NewInsert(table)
+	q.Value("id", ExprDefault{})
+	q.Value("created_at", ExprFunc{Name: "NOW"})
+	q.OnConflict("id")
+	q.UpdateSet("count", ExprOp{Left: ExprColumn{Table: table, Column: "count"}, Op: "+", Right: ExprColumn{Table: q.Excluded(), Column: "count"}})
+
+	sql, _, err := q.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	st := fmt.Sprintf("INSERT INTO \"table\" AS %[1]s (id, created_at) VALUES (DEFAULT, NOW()) ON CONFLICT (id) DO UPDATE SET count = (%[1]s.count + excluded.count)", table.Alias)
+	if sql != st {
+		t.Errorf("bad returned sql. return:\n'%s'\n'%s'", sql, st)
+	}
+}
@@ -17,8 +17,8 @@ func (g GroupColumn) gen(q query) (string, error) {
 			return "", fmt.Errorf("table %s does not exist", g.Table.Name)
 		}
 
-		return g.Table.Alias + "." + g.Column, nil
+		return g.Table.ref(q, g.Column), nil
 	}
 
-	return g.Column, nil
+	return quoteIdent(g.Column), nil
 }
@@ -0,0 +1,144 @@
+package builder
+
+import (
+	"fmt"
+	"strings"
+)
+
+type AggregateFunc string
+
+const (
+	AggregateCount AggregateFunc = "COUNT"
+	AggregateSum   AggregateFunc = "SUM"
+	AggregateAvg   AggregateFunc = "AVG"
+	AggregateMin   AggregateFunc = "MIN"
+	AggregateMax   AggregateFunc = "MAX"
+
+	AggregateBoolAnd   AggregateFunc = "BOOL_AND"
+	AggregateBoolOr    AggregateFunc = "BOOL_OR"
+	AggregateArrayAgg  AggregateFunc = "ARRAY_AGG"
+	AggregateJsonbAgg  AggregateFunc = "JSONB_AGG"
+	AggregateStringAgg AggregateFunc = "STRING_AGG"
+)
+
+// Aggregate is an aggregate expression for HAVING conditions.
+// COUNT without Table or Column is rendered as COUNT(*).
+type Aggregate struct {
+	Func     AggregateFunc // required
+	Table    *Table
+	Column   string
+	Distinct bool
+}
+
+func (a Aggregate) gen(q query) (string, error) {
+	if !exprFuncName.MatchString(string(a.Func)) {
+		return "", fmt.Errorf("bad aggregate func %s", a.Func)
+	}
+
+	if a.Table == nil || a.Column == "" {
+		if a.Func != AggregateCount {
+			return "", fmt.Errorf("column is empty for %s", a.Func)
+		}
+
+		return "COUNT(*)", nil
+	}
+
+	if !q.checkTable(a.Table) {
+		return "", fmt.Errorf("table %s does not exist", a.Table.Name)
+	}
+
+	s := string(a.Func) + "("
+
+	if a.Distinct {
+		s += "DISTINCT "
+	}
+
+	return s + a.Table.ref(q, a.Column) + ")", nil
+}
+
+func (a Aggregate) tag(q query) string {
+	if a.Column != "" {
+		return bindTag(q, strings.ToLower(string(a.Func))+"_"+a.Column)
+	}
+
+	return bindTag(q, strings.ToLower(string(a.Func)))
+}
+
+// genHaving compares the aggregate with Value, which is either a bound value or another Aggregate
+func genHaving(q query, a Aggregate, op string, value any) (string, map[string]any, error) {
+	if q == nil {
+		return "", nil, fmt.Errorf("query cannot be nil")
+	}
+
+	left, err := a.gen(q)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if right, ok := value.(Aggregate); ok {
+		r, err := right.gen(q)
+		if err != nil {
+			return "", nil, err
+		}
+
+		return left + " " + op + " " + r, nil, nil
+	}
+
+	tag := a.tag(q)
+
+	return left + " " + op + " @" + tag, map[string]any{tag: value}, nil
+}
+
+type HavingEq struct {
+	Aggregate Aggregate
+	Value     any
+}
+
+func (h HavingEq) gen(q query) (string, map[string]any, error) {
+	return genHaving(q, h.Aggregate, "=", h.Value)
+}
+
+type HavingNotEq struct {
+	Aggregate Aggregate
+	Value     any
+}
+
+func (h HavingNotEq) gen(q query) (string, map[string]any, error) {
+	return genHaving(q, h.Aggregate, "<>", h.Value)
+}
+
+type HavingMore struct {
+	Aggregate Aggregate
+	Value     any
+}
+
+func (h HavingMore) gen(q query) (string, map[string]any, error) {
+	return genHaving(q, h.Aggregate, ">", h.Value)
+}
+
+type HavingMoreEq struct {
+	Aggregate Aggregate
+	Value     any
+}
+
+func (h HavingMoreEq) gen(q query) (string, map[string]any, error) {
+	return genHaving(q, h.Aggregate, ">=", h.Value)
+}
+
+type HavingLess struct {
+	Aggregate Aggregate
+	Value     any
+}
+
+func (h HavingLess) gen(q query) (string, map[string]any, error) {
+	return genHaving(q, h.Aggregate, "<", h.Value)
+}
+
+type HavingLessEq struct {
+	Aggregate Aggregate
+	Value     any
+}
+
+func (h HavingLessEq) gen(q query) (string, map[string]any, error) {
+	return genHaving(q, h.Aggregate, "<=", h.Value)
+}
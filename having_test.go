@@ -0,0 +1,136 @@
+package builder
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestAggregate_gen(t *testing.T) {
+	table1 := NewTable("table1")
+	table2 := NewTable("table2")
+
+	q := NewSelect()
+	q.From(table1)
+
+	s, err := Aggregate{Func: AggregateCount}.gen(q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != "COUNT(*)" {
+		t.Errorf("bad returned aggregate. return %s", s)
+	}
+
+	s, err = Aggregate{Func: AggregateSum, Table: table1, Column: "col", Distinct: true}.gen(q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != "SUM(DISTINCT "+table1.Alias+".col)" {
+		t.Errorf("bad returned aggregate. return %s", s)
+	}
+
+	if _, err = (Aggregate{Func: AggregateMax}).gen(q); err == nil {
+		t.Errorf("aggregate without column should have error")
+	}
+
+	if _, err = (Aggregate{Func: AggregateMin, Table: table2, Column: "col"}).gen(q); err == nil {
+		t.Errorf("aggregate with unknown table should have error")
+	}
+
+	if _, err = (Aggregate{Table: table1, Column: "col"}).gen(q); err == nil {
+		t.Errorf("aggregate without func should have error")
+	}
+}
+
+func TestHaving_gen(t *testing.T) {
+	table := NewTable("table")
+
+	q := NewSelect()
+	q.From(table)
+
+	sum := Aggregate{Func: AggregateSum, Table: table, Column: "amount"}
+	avg := Aggregate{Func: AggregateAvg, Table: table, Column: "amount"}
+
+	tests := []struct {
+		where Where
+		op    string
+	}{
+		{HavingEq{Aggregate: sum, Value: 10}, "="},
+		{HavingNotEq{Aggregate: sum, Value: 10}, "<>"},
+		{HavingMore{Aggregate: sum, Value: 10}, ">"},
+		{HavingMoreEq{Aggregate: sum, Value: 10}, ">="},
+		{HavingLess{Aggregate: sum, Value: 10}, "<"},
+		{HavingLessEq{Aggregate: sum, Value: 10}, "<="},
+	}
+
+	for _, tt := range tests {
+		sql, binds, err := tt.where.gen(q)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(binds) != 1 {
+			t.Fatalf("binds should have 1 values")
+		}
+
+		var tag string
+
+		for k, v := range binds {
+			if v != 10 {
+				t.Errorf("value should have 10")
+			}
+
+			tag = k
+		}
+
+		if sql != "SUM("+table.Alias+".amount) "+tt.op+" @"+tag {
+			t.Errorf("bad returned sql. return %s", sql)
+		}
+	}
+
+	sql, binds, err := HavingMore{Aggregate: sum, Value: avg}.gen(q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(binds) != 0 {
+		t.Errorf("binds should have 0 values")
+	}
+	if sql != "SUM("+table.Alias+".amount) > AVG("+table.Alias+".amount)" {
+		t.Errorf("bad returned sql. return %s", sql)
+	}
+}
+
+func TestSelectQuery_Having(t *testing.T) {
+	table := NewTable("table")
+
+	q := NewSelect()
+	q.From(table)
+	q.Column(ColumnName{Table: table, Name: "user_id"})
+	q.Group(GroupColumn{Table: table, Column: "user_id"})
+	q.Having(WhereAnd{List: []Where{
+		HavingMore{Aggregate: Aggregate{Func: AggregateCount}, Value: 5},
+		HavingLess{Aggregate: Aggregate{Func: AggregateMax, Table: table, Column: "amount"}, Value: 100},
+	}})
+	q.Order(Order{Table: table, Column: "user_id"})
+
+	sql, binds, err := q.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(binds) != 2 {
+		t.Fatalf("binds should have 2 values")
+	}
+
+	var c, m string
+
+	for k, v := range binds {
+		if v == 5 {
+			c = k
+		} else if v == 100 {
+			m = k
+		}
+	}
+
+	st := fmt.Sprintf("SELECT %[1]s.user_id FROM \"table\" AS %[1]s GROUP BY %[1]s.user_id HAVING (COUNT(*) > @%[2]s AND MAX(%[1]s.amount) < @%[3]s) ORDER BY %[1]s.user_id", table.Alias, c, m)
+	if sql != st {
+		t.Errorf("bad returned sql. return:\n'%s'\n'%s'", sql, st)
+	}
+}
@@ -2,39 +2,102 @@ package builder
 
 import (
 	"fmt"
+	"maps"
 	"strings"
 )
 
 type InsertQuery struct {
-	table    *Table
-	values   []insertValue
-	conflict []string
-	update   []set
-	returns  []Column
-	binds    map[string]any
+	with
+	namer
+	scope
+	table         *Table
+	values        []insertValue
+	columns       []string
+	rows          []insertRow
+	sel           *SelectQuery
+	conflict      []string
+	constraint    string
+	conflictWhere Where
+	doNothing     bool
+	updateWhere   Where
+	excluded      *Table
+	update        []set
+	returns       []Column
+	binds         map[string]any
+	err           error
 }
 
 func NewInsert(table *Table) *InsertQuery {
 	return &InsertQuery{
-		table: table,
-		binds: make(map[string]any),
+		table:    table,
+		namer:    newNamer(),
+		excluded: &Table{Name: "excluded", Alias: "excluded"},
+		binds:    make(map[string]any),
 	}
 }
 
 func (q *InsertQuery) checkTable(table *Table) bool {
-	return q.table == table
+	return q.table == table || q.excluded == table || q.checkOuter(table)
 }
 
 func (q *InsertQuery) addBind(key string, value any) {
 	q.binds[key] = value
 }
 
+func (q *InsertQuery) names() *namer {
+	return &q.namer
+}
+
+// Naming sets the alias and bind naming of the query, see Naming
+func (q *InsertQuery) Naming(n Naming) *InsertQuery {
+	q.namer.mode = n
+
+	return q
+}
+
 func (q *InsertQuery) Value(column string, v any) *InsertQuery {
 	q.values = append(q.values, insertValue{Column: column, Value: v})
 
 	return q
 }
 
+// Columns sets the column list for rows added with Row and Rows
+func (q *InsertQuery) Columns(c ...string) *InsertQuery {
+	q.columns = append(q.columns, c...)
+
+	return q
+}
+
+// Row adds a VALUES tuple in the order of Columns
+func (q *InsertQuery) Row(v ...any) *InsertQuery {
+	q.rows = append(q.rows, insertRow{Values: v})
+
+	return q
+}
+
+// Rows adds VALUES tuples from maps keyed by column, every map must have all Columns
+func (q *InsertQuery) Rows(rows ...map[string]any) *InsertQuery {
+	for _, r := range rows {
+		q.rows = append(q.rows, insertRow{Named: r})
+	}
+
+	return q
+}
+
+// FromSelect inserts rows returned by sel into columns
+func (q *InsertQuery) FromSelect(columns []string, sel *SelectQuery) *InsertQuery {
+	if sel == nil {
+		q.err = fmt.Errorf("insert select cannot be nil")
+
+		return q
+	}
+
+	q.columns = columns
+	q.sel = sel
+
+	return q
+}
+
 func (q *InsertQuery) Return(c ...Column) *InsertQuery {
 	q.returns = append(q.returns, c...)
 
@@ -47,6 +110,31 @@ func (q *InsertQuery) OnConflict(c ...string) *InsertQuery {
 	return q
 }
 
+// OnConflictConstraint uses ON CONFLICT ON CONSTRAINT name as conflict target
+func (q *InsertQuery) OnConflictConstraint(name string) *InsertQuery {
+	q.constraint = name
+
+	return q
+}
+
+// OnConflictWhere sets the partial index predicate of the conflict target
+func (q *InsertQuery) OnConflictWhere(w Where) *InsertQuery {
+	q.conflictWhere = w
+
+	return q
+}
+
+func (q *InsertQuery) OnConflictDoNothing() *InsertQuery {
+	q.doNothing = true
+
+	return q
+}
+
+// Excluded returns the EXCLUDED row of ON CONFLICT DO UPDATE for use in conditions
+func (q *InsertQuery) Excluded() *Table {
+	return q.excluded
+}
+
 func (q *InsertQuery) UpdateSet(column string, value any) *InsertQuery {
 	q.update = append(q.update, set{Column: column, Value: value})
 
@@ -59,7 +147,36 @@ func (q *InsertQuery) UpdateSetNow(column string) *InsertQuery {
 	return q
 }
 
+// UpdateSetExcluded sets column = EXCLUDED.column
+func (q *InsertQuery) UpdateSetExcluded(column string) *InsertQuery {
+	q.update = append(q.update, set{Column: column, Excluded: true})
+
+	return q
+}
+
+// UpdateSetExcludedOp sets column = table.column op EXCLUDED.column, op is one of + - * / ||
+func (q *InsertQuery) UpdateSetExcludedOp(column string, op string) *InsertQuery {
+	q.update = append(q.update, set{Column: column, Excluded: true, Op: op})
+
+	return q
+}
+
+// UpdateWhere sets the condition of ON CONFLICT DO UPDATE
+func (q *InsertQuery) UpdateWhere(w Where) *InsertQuery {
+	q.updateWhere = w
+
+	return q
+}
+
 func (q *InsertQuery) getValues() (string, error) {
+	if q.sel != nil {
+		return q.getSelect()
+	}
+
+	if len(q.rows) != 0 {
+		return q.getRows()
+	}
+
 	if len(q.values) == 0 {
 		return "", fmt.Errorf("no values")
 	}
@@ -67,12 +184,13 @@ func (q *InsertQuery) getValues() (string, error) {
 	var c, t string
 
 	for i, v := range q.values {
-		tag := v.Column + "_" + randStr()
-
-		c += v.Column
-		t += "@" + tag
+		value, err := genValue(q, v.Column, v.Value)
+		if err != nil {
+			return "", err
+		}
 
-		q.addBind(tag, v.Value)
+		c += quoteIdent(v.Column)
+		t += value
 
 		if i < len(q.values)-1 {
 			c += ", "
@@ -83,32 +201,164 @@ func (q *InsertQuery) getValues() (string, error) {
 	return " (" + c + ") VALUES (" + t + ")", nil
 }
 
-func (q *InsertQuery) getConflict() string {
-	if len(q.conflict) == 0 {
-		return ""
+func (q *InsertQuery) getRows() (string, error) {
+	if len(q.values) != 0 {
+		return "", fmt.Errorf("cannot mix Value with Row")
+	}
+
+	if len(q.columns) == 0 {
+		return "", fmt.Errorf("no columns")
+	}
+
+	rows := make([]string, len(q.rows))
+
+	for i, row := range q.rows {
+		values := row.Values
+
+		if row.Named != nil {
+			if len(row.Named) != len(q.columns) {
+				return "", fmt.Errorf("row %d has %d values, expected %d", i, len(row.Named), len(q.columns))
+			}
+
+			values = make([]any, len(q.columns))
+
+			for j, c := range q.columns {
+				v, ok := row.Named[c]
+				if !ok {
+					return "", fmt.Errorf("row %d has no column %s", i, c)
+				}
+
+				values[j] = v
+			}
+		}
+
+		if len(values) != len(q.columns) {
+			return "", fmt.Errorf("row %d has %d values, expected %d", i, len(values), len(q.columns))
+		}
+
+		list := make([]string, len(values))
+
+		for j, v := range values {
+			value, err := genValue(q, q.columns[j], v)
+			if err != nil {
+				return "", err
+			}
+
+			list[j] = value
+		}
+
+		rows[i] = "(" + strings.Join(list, ", ") + ")"
+	}
+
+	return " (" + quoteIdents(q.columns) + ") VALUES " + strings.Join(rows, ", "), nil
+}
+
+func (q *InsertQuery) getSelect() (string, error) {
+	if len(q.values) != 0 || len(q.rows) != 0 {
+		return "", fmt.Errorf("cannot mix FromSelect with values")
+	}
+
+	if len(q.columns) == 0 {
+		return "", fmt.Errorf("no columns")
+	}
+
+	sql, binds, err := getDerived(q, q.sel)
+	if err != nil {
+		return "", err
+	}
+
+	for k, v := range binds {
+		q.addBind(k, v)
+	}
+
+	return " (" + quoteIdents(q.columns) + ") " + sql, nil
+}
+
+func (q *InsertQuery) getConflict() (string, error) {
+	if len(q.conflict) == 0 && q.constraint == "" && !q.doNothing && len(q.update) == 0 {
+		return "", nil
+	}
+
+	s := " ON CONFLICT"
+
+	switch {
+	case q.constraint != "" && len(q.conflict) != 0:
+		return "", fmt.Errorf("cannot use both conflict columns and constraint")
+	case q.constraint != "":
+		if q.conflictWhere != nil {
+			return "", fmt.Errorf("conflict where requires conflict columns")
+		}
+
+		s += " ON CONSTRAINT " + quoteIdent(q.constraint)
+	case len(q.conflict) != 0:
+		s += " (" + quoteIdents(q.conflict) + ")"
+
+		if q.conflictWhere != nil {
+			where, binds, err := q.conflictWhere.gen(q)
+			if err != nil {
+				return "", err
+			}
+
+			for k, v := range binds {
+				q.addBind(k, v)
+			}
+
+			if where != "" {
+				s += " WHERE " + where
+			}
+		}
+	}
+
+	if q.doNothing {
+		if len(q.update) != 0 {
+			return "", fmt.Errorf("cannot use both do nothing and update set")
+		}
+
+		return s + " DO NOTHING", nil
+	}
+
+	if len(q.conflict) == 0 && q.constraint == "" {
+		return "", fmt.Errorf("do update requires conflict columns or constraint")
+	}
+
+	update, err := q.getUpdate()
+	if err != nil {
+		return "", err
 	}
 
-	return " ON CONFLICT (" + strings.Join(q.conflict, ", ") + ")"
+	return s + update, nil
 }
 
-func (q *InsertQuery) getUpdate() string {
-	if len(q.update) == 0 || len(q.conflict) == 0 {
-		return ""
+func (q *InsertQuery) getUpdate() (string, error) {
+	if len(q.update) == 0 {
+		return "", fmt.Errorf("no action for on conflict")
 	}
 
 	s := " DO UPDATE SET "
 
 	for i, st := range q.update {
-		s += st.Column + " = "
+		s += quoteIdent(st.Column) + " = "
 
-		if st.Now {
+		switch {
+		case st.Now:
 			s += "NOW()"
-		} else {
-			tag := st.Column + "_" + randStr()
-
-			s += "@" + tag
-
-			q.addBind(tag, st.Value)
+		case st.Excluded && st.Op != "":
+			switch st.Op {
+			case "+", "-", "*", "/", "||":
+			default:
+				return "", fmt.Errorf("bad operator %s", st.Op)
+			}
+
+			s += q.table.ref(q, st.Column) + " " + st.Op + " " + q.Excluded().ref(q, st.Column)
+		case st.Excluded:
+			s += q.Excluded().ref(q, st.Column)
+		default:
+			v, err := genValue(q, st.Column, st.Value)
+			if err != nil {
+				return "", err
+			}
+
+			s += v
 		}
 
 		if i != len(q.update)-1 {
@@ -116,7 +366,22 @@ func (q *InsertQuery) getUpdate() string {
 		}
 	}
 
-	return s
+	if q.updateWhere != nil {
+		where, binds, err := q.updateWhere.gen(q)
+		if err != nil {
+			return "", err
+		}
+
+		for k, v := range binds {
+			q.addBind(k, v)
+		}
+
+		if where != "" {
+			s += " WHERE " + where
+		}
+	}
+
+	return s, nil
 }
 
 func (q *InsertQuery) getReturns() (string, error) {
@@ -142,20 +407,70 @@ func (q *InsertQuery) getReturns() (string, error) {
 	return " RETURNING " + s, nil
 }
 
+// GetPositional returns SQL with $1..$n placeholders and ordered arguments for database/sql, lib/pq and pgx
+func (q *InsertQuery) GetPositional() (string, []any, error) {
+	return getPositional(q)
+}
+
 func (q *InsertQuery) Get() (string, map[string]any, error) {
+	return q.render(nil, nil)
+}
+
+// render builds a copy of the query, so Get() leaves the query untouched
+func (q *InsertQuery) render(root *namer, outer query) (string, map[string]any, error) {
+	return q.namer.get(root, func(root *namer) (string, map[string]any, error) {
+		c := q.clone()
+		c.namer.root = root
+		c.scope.parent = outer
+
+		return c.get()
+	})
+}
+
+func (q *InsertQuery) clone() *InsertQuery {
+	c := *q
+	c.binds = maps.Clone(q.binds)
+
+	return &c
+}
+
+func (q *InsertQuery) get() (string, map[string]any, error) {
+	if q.err != nil {
+		return "", nil, q.err
+	}
+
 	if q.table == nil {
 		return "", nil, fmt.Errorf("table not set")
 	}
 
+	if q.table.Only {
+		return "", nil, fmt.Errorf("insert table cannot be only")
+	}
+
+	table, err := q.table.target(q)
+	if err != nil {
+		return "", nil, err
+	}
+
+	with, err := q.getWith(q)
+	if err != nil {
+		return "", nil, err
+	}
+
 	values, err := q.getValues()
 	if err != nil {
 		return "", nil, err
 	}
 
+	conflict, err := q.getConflict()
+	if err != nil {
+		return "", nil, err
+	}
+
 	returns, err := q.getReturns()
 	if err != nil {
 		return "", nil, err
 	}
 
-	return "INSERT INTO " + q.table.Name + " AS " + q.table.Alias + values + q.getConflict() + q.getUpdate() + returns, q.binds, nil
+	return with + "INSERT INTO " + table + values + conflict + returns, q.binds, nil
 }
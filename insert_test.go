@@ -158,42 +158,221 @@ func TestInsertQuery_getValues(t *testing.T) {
 	}
 }
 
+func TestInsertQuery_getRows(t *testing.T) {
+	table := NewTable("table")
+	q := NewInsert(table)
+
+	q.Columns("col1", "col2")
+	q.Row(1, "a")
+	q.Rows(map[string]any{"col2": "b", "col1": 2})
+
+	values, err := q.getValues()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(q.binds) != 4 {
+		t.Fatalf("q.binds should have 4 values")
+	}
+
+	tags := make(map[any]string)
+
+	for k, v := range q.binds {
+		tags[v] = k
+	}
+
+	if values != fmt.Sprintf(" (col1, col2) VALUES (@%s, @%s), (@%s, @%s)", tags[1], tags["a"], tags[2], tags["b"]) {
+		t.Errorf("q.getValues() returned %v", values)
+	}
+
+	q.Row(3)
+
+	if _, err = q.getValues(); err == nil {
+		t.Error("expected error")
+	}
+
+	q = NewInsert(table)
+	q.Columns("col1", "col2")
+	q.Rows(map[string]any{"col1": 1, "col3": 2})
+
+	if _, err = q.getValues(); err == nil {
+		t.Error("expected error")
+	}
+
+	q = NewInsert(table)
+	q.Row(1)
+
+	if _, err = q.getValues(); err == nil {
+		t.Error("expected error")
+	}
+
+	q = NewInsert(table)
+	q.Columns("col1")
+	q.Row(1)
+	q.Value("col2", 2)
+
+	if _, err = q.getValues(); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestInsertQuery_FromSelect(t *testing.T) {
+	table1 := NewTable("table1")
+	table2 := NewTable("table2")
+
+	sel := NewSelect()
+	sel.From(table2)
+	sel.Column(ColumnName{Table: table2, Name: "col1"}, ColumnName{Table: table2, Name: "col2"})
+	sel.Where(WhereEq{Table: table2, Column: "col3", Value: 5})
+
+	q := NewInsert(table1)
+	q.FromSelect([]string{"col1", "col2"}, sel)
+	q.OnConflict("col1")
+	q.UpdateSet("col2", "val")
+	q.Return(ColumnName{Table: table1, Name: "id"})
+
+	sql, binds, err := q.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(binds) != 2 {
+		t.Fatalf("binds should have 2 values")
+	}
+
+	var tag1, tag2 string
+
+	for k, v := range binds {
+		if v == 5 {
+			tag1 = k
+		} else if v == "val" {
+			tag2 = k
+		}
+	}
+
+	st := fmt.Sprintf("INSERT INTO table1 AS %[1]s (col1, col2) SELECT %[2]s.col1, %[2]s.col2 FROM table2 AS %[2]s WHERE %[2]s.col3 = @%[3]s ON CONFLICT (col1) DO UPDATE SET col2 = @%[4]s RETURNING %[1]s.id", table1.Alias, table2.Alias, tag1, tag2)
+	if sql != st {
+		t.Errorf("bad returned sql. return:\n'%s'\n'%s'", sql, st)
+	}
+
+	q.Value("col3", 1)
+
+	if _, _, err = q.Get(); err == nil {
+		t.Error("expected error")
+	}
+
+	q = NewInsert(table1)
+	q.FromSelect(nil, sel)
+
+	if _, _, err = q.Get(); err == nil {
+		t.Error("expected error")
+	}
+
+	q = NewInsert(table1)
+	q.FromSelect([]string{"col1"}, nil).Value("col1", 1)
+
+	if _, _, err = q.Get(); err == nil {
+		t.Error("expected error for nil select")
+	}
+}
+
 func TestInsertQuery_getConflict(t *testing.T) {
 	table := NewTable("table")
 	q := NewInsert(table)
 
-	sql := q.getConflict()
+	sql, err := q.getConflict()
+	if err != nil {
+		t.Fatal(err)
+	}
 	if sql != "" {
 		t.Errorf("q.getConflict() returned %v", sql)
 	}
 
 	q.OnConflict("col1", "col2")
 
-	sql = q.getConflict()
-	if sql != " ON CONFLICT (col1, col2)" {
+	if _, err = q.getConflict(); err == nil {
+		t.Errorf("q.getConflict() should have error without action")
+	}
+
+	q.OnConflictDoNothing()
+
+	sql, err = q.getConflict()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sql != " ON CONFLICT (col1, col2) DO NOTHING" {
+		t.Errorf("q.getConflict() returned %v", sql)
+	}
+
+	q.OnConflictWhere(WhereIsNull{Table: table, Column: "deleted_at"})
+
+	sql, err = q.getConflict()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sql != " ON CONFLICT (col1, col2) WHERE "+table.Alias+".deleted_at IS NULL DO NOTHING" {
+		t.Errorf("q.getConflict() returned %v", sql)
+	}
+
+	q.OnConflictConstraint("table_pkey")
+
+	if _, err = q.getConflict(); err == nil {
+		t.Errorf("q.getConflict() should have error with columns and constraint")
+	}
+
+	q = NewInsert(table)
+	q.OnConflictConstraint("table_pkey")
+	q.UpdateSetExcluded("col1")
+
+	sql, err = q.getConflict()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sql != " ON CONFLICT ON CONSTRAINT table_pkey DO UPDATE SET col1 = excluded.col1" {
 		t.Errorf("q.getConflict() returned %v", sql)
 	}
+
+	q.OnConflictDoNothing()
+
+	if _, err = q.getConflict(); err == nil {
+		t.Errorf("q.getConflict() should have error with do nothing and update")
+	}
+
+	q = NewInsert(table)
+	q.OnConflictDoNothing()
+
+	sql, err = q.getConflict()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sql != " ON CONFLICT DO NOTHING" {
+		t.Errorf("q.getConflict() returned %v", sql)
+	}
+
+	q = NewInsert(table)
+	q.UpdateSet("col1", 1)
+
+	if _, err = q.getConflict(); err == nil {
+		t.Errorf("q.getConflict() should have error without target")
+	}
 }
 
 func TestInsertQuery_getUpdate(t *testing.T) {
 	table := NewTable("table")
 	q := NewInsert(table)
 
-	sql := q.getUpdate()
-	if sql != "" {
-		t.Errorf("q.getUpdate() returned %v", sql)
+	if _, err := q.getUpdate(); err == nil {
+		t.Errorf("q.getUpdate() should have error")
 	}
 
 	q.OnConflict("col1")
-	sql = q.getUpdate()
-	if sql != "" {
-		t.Errorf("q.getUpdate() returned %v", sql)
-	}
-
 	q.UpdateSet("col1", "value1")
 	q.UpdateSetNow("col2")
+	q.UpdateSetExcluded("col3")
+	q.UpdateSetExcludedOp("col4", "+")
 
-	sql = q.getUpdate()
+	sql, err := q.getUpdate()
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	var tag string
 
@@ -203,8 +382,32 @@ func TestInsertQuery_getUpdate(t *testing.T) {
 		}
 	}
 
-	if sql != " DO UPDATE SET col1 = @"+tag+", col2 = NOW()" {
-		t.Errorf("q.getSet() returned '%v'", sql)
+	if sql != " DO UPDATE SET col1 = @"+tag+", col2 = NOW(), col3 = excluded.col3, col4 = "+table.Alias+".col4 + excluded.col4" {
+		t.Errorf("q.getUpdate() returned '%v'", sql)
+	}
+
+	q.UpdateWhere(WhereMoreColumn{Table1: q.Excluded(), Column1: "version", Table2: table, Column2: "version"})
+	q.binds = make(map[string]any)
+
+	sql, err = q.getUpdate()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for k, v := range q.binds {
+		if v == "value1" {
+			tag = k
+		}
+	}
+
+	if sql != " DO UPDATE SET col1 = @"+tag+", col2 = NOW(), col3 = excluded.col3, col4 = "+table.Alias+".col4 + excluded.col4 WHERE excluded.version > "+table.Alias+".version" {
+		t.Errorf("q.getUpdate() returned '%v'", sql)
+	}
+
+	q.UpdateSetExcludedOp("col5", "; DROP")
+
+	if _, err = q.getUpdate(); err == nil {
+		t.Errorf("q.getUpdate() should have error with bad operator")
 	}
 }
 
@@ -266,7 +469,37 @@ func TestInsertQuery_Get(t *testing.T) {
 		t.Errorf("q.Get() should have 2 values")
 	}
 
-	if sql != fmt.Sprintf("INSERT INTO %[1]s AS %[2]s (col1, col2) VALUES (@%[3]s, @%[4]s) ON CONFLICT (col1) DO UPDATE SET col2 = @%[5]s RETURNING %[2]s.col1, %[2]s.col2 AS a1", table.Name, table.Alias, tag1, tag2, tag3) {
+	if sql != fmt.Sprintf("INSERT INTO \"%[1]s\" AS %[2]s (col1, col2) VALUES (@%[3]s, @%[4]s) ON CONFLICT (col1) DO UPDATE SET col2 = @%[5]s RETURNING %[2]s.col1, %[2]s.col2 AS a1", table.Name, table.Alias, tag1, tag2, tag3) {
 		t.Errorf("q.Get() returned '%v'", sql)
 	}
 }
+
+func TestInsertQuery_Get_repeat(t *testing.T) {
+	table := NewTable("table")
+
+	q := NewInsert(table)
+	q.Value("col1", 1)
+	q.Value("col2", "v")
+	q.OnConflict("col1")
+	q.UpdateSet("col2", "w")
+
+	sql1, binds1, err := q.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sql2, binds2, err := q.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if sql1 != sql2 {
+		t.Errorf("sql is changed. return:\n'%s'\n'%s'", sql1, sql2)
+	}
+	if len(binds1) != 3 || len(binds2) != 3 {
+		t.Errorf("binds should have 3 values, have %d and %d", len(binds1), len(binds2))
+	}
+	if len(q.binds) != 0 {
+		t.Errorf("q.binds should have 0 values")
+	}
+}
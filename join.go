@@ -1,23 +1,81 @@
 package builder
 
+import "fmt"
+
+type joinType int
+
+const (
+	joinInner joinType = iota
+	joinLeft
+	joinRight
+	joinFull
+	joinCross
+)
+
+func (t joinType) String() string {
+	switch t {
+	case joinLeft:
+		return "LEFT JOIN"
+	case joinRight:
+		return "RIGHT JOIN"
+	case joinFull:
+		return "FULL JOIN"
+	case joinCross:
+		return "CROSS JOIN"
+	default:
+		return "JOIN"
+	}
+}
+
 type join struct {
 	Table *Table
 	On    On
 	Used  bool
-	Left  bool
+	Type  joinType
+	deps  []*join
+}
+
+// dependsOn reports whether ON of j references other, directly or through other joins
+func (j *join) dependsOn(other *join, seen map[*join]bool) bool {
+	if seen[j] {
+		return false
+	}
+
+	seen[j] = true
+
+	for _, d := range j.deps {
+		if d == other || d.dependsOn(other, seen) {
+			return true
+		}
+	}
+
+	return false
 }
 
 func (j join) Gen(query query) (string, error) {
-	on, err := j.On.gen(query)
+	table, binds, err := j.Table.gen(query)
 	if err != nil {
 		return "", err
 	}
 
-	s := ""
+	for k, v := range binds {
+		query.addBind(k, v)
+	}
+
+	s := " " + j.Type.String() + " " + table
+
+	if j.Type == joinCross {
+		return s, nil
+	}
 
-	if j.Left {
-		s += " LEFT"
+	if j.On == nil {
+		return "", fmt.Errorf("on is empty for join %s", j.Table.Name)
+	}
+
+	on, err := j.On.gen(query)
+	if err != nil {
+		return "", err
 	}
 
-	return s + " JOIN " + j.Table.Name + " AS " + j.Table.Alias + " ON " + on, nil
+	return s + " ON " + on, nil
 }
@@ -56,7 +56,7 @@ func (o OnEq) gen(q query) (string, error) {
 		return "", fmt.Errorf("table %s does not exist", o.Table2.Name)
 	}
 
-	return o.Table1.Alias + "." + o.Column1 + " = " + o.Table2.Alias + "." + o.Column2, nil
+	return o.Table1.ref(q, o.Column1) + " = " + o.Table2.ref(q, o.Column2), nil
 }
 
 type OnLess struct {
@@ -79,7 +79,7 @@ func (o OnLess) gen(q query) (string, error) {
 		return "", fmt.Errorf("table %s does not exist", o.Table2.Name)
 	}
 
-	return o.Table1.Alias + "." + o.Column1 + " < " + o.Table2.Alias + "." + o.Column2, nil
+	return o.Table1.ref(q, o.Column1) + " < " + o.Table2.ref(q, o.Column2), nil
 }
 
 type OnMore struct {
@@ -102,5 +102,5 @@ func (o OnMore) gen(q query) (string, error) {
 		return "", fmt.Errorf("table %s does not exist", o.Table2.Name)
 	}
 
-	return o.Table1.Alias + "." + o.Column1 + " > " + o.Table2.Alias + "." + o.Column2, nil
+	return o.Table1.ref(q, o.Column1) + " > " + o.Table2.ref(q, o.Column2), nil
 }
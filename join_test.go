@@ -31,7 +31,7 @@ func TestJoin_Gen(t *testing.T) {
 		t.Fatal(sql)
 	}
 
-	j.Left = true
+	j.Type = joinLeft
 
 	sql, err = j.Gen(q)
 	if err != nil {
@@ -40,4 +40,33 @@ func TestJoin_Gen(t *testing.T) {
 	if sql != fmt.Sprintf(" LEFT JOIN %[1]s AS %[2]s ON %[2]s.id = %[3]s.table_id", table1.Name, table1.Alias, table2.Alias) {
 		t.Fatal(sql)
 	}
+
+	for typ, name := range map[joinType]string{joinRight: "RIGHT JOIN", joinFull: "FULL JOIN"} {
+		j.Type = typ
+
+		sql, err = j.Gen(q)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if sql != fmt.Sprintf(" %[4]s %[1]s AS %[2]s ON %[2]s.id = %[3]s.table_id", table1.Name, table1.Alias, table2.Alias, name) {
+			t.Fatal(sql)
+		}
+	}
+
+	j.Type = joinCross
+
+	sql, err = j.Gen(q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sql != fmt.Sprintf(" CROSS JOIN %[1]s AS %[2]s", table1.Name, table1.Alias) {
+		t.Fatal(sql)
+	}
+
+	j.Type = joinInner
+	j.On = nil
+
+	if _, err = j.Gen(q); err == nil {
+		t.Fatal("j.Gen should have error without on")
+	}
 }
@@ -0,0 +1,160 @@
+package builder
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// WhereKeyset selects rows after the last seen row for keyset pagination.
+// Values are the last seen row values in the same order as Order.
+type WhereKeyset struct {
+	Order  []Order
+	Values []any
+}
+
+func (w WhereKeyset) gen(q query) (string, map[string]any, error) {
+	if q == nil {
+		return "", nil, fmt.Errorf("query cannot be nil")
+	}
+
+	if len(w.Order) == 0 {
+		return "", nil, fmt.Errorf("keyset requires order")
+	}
+
+	if len(w.Order) != len(w.Values) {
+		return "", nil, fmt.Errorf("keyset has %d values for %d order columns", len(w.Values), len(w.Order))
+	}
+
+	var (
+		columns = make([]string, len(w.Order))
+		tags    = make([]string, len(w.Order))
+		binds   = make(map[string]any)
+		mixed   = false
+	)
+
+	for i, o := range w.Order {
+		sql, err := keysetColumn(q, o)
+		if err != nil {
+			return "", nil, err
+		}
+
+		tag := bindTag(q, o.Column)
+
+		columns[i] = sql
+		tags[i] = "@" + tag
+		binds[tag] = w.Values[i]
+
+		if o.Desc != w.Order[0].Desc {
+			mixed = true
+		}
+	}
+
+	if !mixed {
+		op := " > "
+
+		if w.Order[0].Desc {
+			op = " < "
+		}
+
+		if len(columns) == 1 {
+			return columns[0] + op + tags[0], binds, nil
+		}
+
+		return "(" + strings.Join(columns, ", ") + ")" + op + "(" + strings.Join(tags, ", ") + ")", binds, nil
+	}
+
+	list := make([]string, len(columns))
+
+	for i := range columns {
+		op := " > "
+
+		if w.Order[i].Desc {
+			op = " < "
+		}
+
+		and := make([]string, 0, i+1)
+
+		for j := 0; j < i; j++ {
+			and = append(and, columns[j]+" = "+tags[j])
+		}
+
+		and = append(and, columns[i]+op+tags[i])
+
+		if len(and) == 1 {
+			list[i] = and[0]
+		} else {
+			list[i] = "(" + strings.Join(and, " AND ") + ")"
+		}
+	}
+
+	return "(" + strings.Join(list, " OR ") + ")", binds, nil
+}
+
+// keysetColumn renders the column of o for WHERE. An Order without table may name an output alias,
+// which WHERE cannot reference, so the aliased column of the select is used instead.
+func keysetColumn(q query, o Order) (string, error) {
+	s, ok := q.(*SelectQuery)
+	if o.Table != nil || !ok {
+		return Order{Table: o.Table, Column: o.Column}.gen(q)
+	}
+
+	for _, c := range s.columns {
+		if name, ok := c.(ColumnName); ok {
+			if name.Alias == o.Column {
+				return Order{Table: name.Table, Column: name.Name}.gen(q)
+			}
+
+			continue
+		}
+
+		v := reflect.ValueOf(c)
+		if v.Kind() != reflect.Struct {
+			continue
+		}
+
+		if f := v.FieldByName("Alias"); f.IsValid() && f.String() == o.Column {
+			return "", fmt.Errorf("keyset column %s is an alias of an expression, order by a table column", o.Column)
+		}
+	}
+
+	return Order{Column: o.Column}.gen(q)
+}
+
+// EncodeCursor packs the last seen row values into an opaque cursor
+func EncodeCursor(values ...any) (string, error) {
+	b, err := json.Marshal(values)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// DecodeCursor unpacks a cursor made by EncodeCursor into dst pointers
+func DecodeCursor(cursor string, dst ...any) error {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return fmt.Errorf("bad cursor: %w", err)
+	}
+
+	var list []json.RawMessage
+
+	if err = json.Unmarshal(b, &list); err != nil {
+		return fmt.Errorf("bad cursor: %w", err)
+	}
+
+	if len(list) != len(dst) {
+		return fmt.Errorf("cursor has %d values, expected %d", len(list), len(dst))
+	}
+
+	for i, raw := range list {
+		if err = json.Unmarshal(raw, dst[i]); err != nil {
+			return fmt.Errorf("bad cursor value %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
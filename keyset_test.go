@@ -0,0 +1,181 @@
+package builder
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestWhereKeyset_gen(t *testing.T) {
+	table := NewTable("table")
+	q := NewSelect()
+	q.From(table)
+
+	where := WhereKeyset{
+		Order:  []Order{{Table: table, Column: "created_at"}, {Table: table, Column: "id"}},
+		Values: []any{"2024-01-01", 10},
+	}
+
+	sql, binds, err := where.gen(q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(binds) != 2 {
+		t.Fatalf("binds should have 2 values")
+	}
+
+	var c, i string
+
+	for k, v := range binds {
+		if v == 10 {
+			i = k
+		} else {
+			c = k
+		}
+	}
+
+	if sql != fmt.Sprintf("(%[1]s.created_at, %[1]s.id) > (@%[2]s, @%[3]s)", table.Alias, c, i) {
+		t.Errorf("bad returned sql. return %s", sql)
+	}
+
+	where.Order[0].Desc = true
+	where.Order[1].Desc = true
+
+	sql, binds, err = where.gen(q)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for k, v := range binds {
+		if v == 10 {
+			i = k
+		} else {
+			c = k
+		}
+	}
+
+	if sql != fmt.Sprintf("(%[1]s.created_at, %[1]s.id) < (@%[2]s, @%[3]s)", table.Alias, c, i) {
+		t.Errorf("bad returned sql. return %s", sql)
+	}
+
+	where.Order[1].Desc = false
+
+	sql, binds, err = where.gen(q)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for k, v := range binds {
+		if v == 10 {
+			i = k
+		} else {
+			c = k
+		}
+	}
+
+	if sql != fmt.Sprintf("(%[1]s.created_at < @%[2]s OR (%[1]s.created_at = @%[2]s AND %[1]s.id > @%[3]s))", table.Alias, c, i) {
+		t.Errorf("bad returned sql. return %s", sql)
+	}
+
+	if _, _, err = (WhereKeyset{Order: where.Order, Values: []any{1}}).gen(q); err == nil {
+		t.Error("expected error")
+	}
+	if _, _, err = (WhereKeyset{}).gen(q); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestSelectQuery_After(t *testing.T) {
+	table := NewTable("table")
+
+	q := NewSelect()
+	q.From(table)
+	q.Column(ColumnName{Table: table, Name: "id"})
+	q.Where(WhereEq{Table: table, Column: "user_id", Value: 5})
+	q.Order(Order{Table: table, Column: "id", Desc: true})
+	q.After(100)
+	q.Limit(20)
+
+	sql, binds, err := q.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(binds) != 3 {
+		t.Fatalf("binds should have 3 values")
+	}
+
+	var u, i, l string
+
+	for k, v := range binds {
+		switch v {
+		case 5:
+			u = k
+		case 100:
+			i = k
+		case 20:
+			l = k
+		}
+	}
+
+	st := fmt.Sprintf("SELECT %[1]s.id FROM \"table\" AS %[1]s WHERE %[1]s.user_id = @%[2]s AND %[1]s.id < @%[3]s ORDER BY %[1]s.id DESC LIMIT @%[4]s", table.Alias, u, i, l)
+	if sql != st {
+		t.Errorf("bad returned sql. return:\n'%s'\n'%s'", sql, st)
+	}
+}
+
+func TestSelectQuery_After_alias(t *testing.T) {
+	table := &Table{Name: "users", Alias: "u"}
+
+	q := NewSelect().Naming(NamingSequential)
+	q.From(table)
+	q.Column(ColumnName{Table: table, Name: "id", Alias: "uid"})
+	q.Order(Order{Column: "uid"})
+	q.After(100)
+
+	sql, _, err := q.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sql != "SELECT u.id AS uid FROM users AS u WHERE u.id > @uid_1 ORDER BY uid" {
+		t.Errorf("bad returned sql. return %s", sql)
+	}
+
+	q = NewSelect()
+	q.From(table)
+	q.Column(ColumnCount{Alias: "c"})
+	q.Order(Order{Column: "c"})
+	q.After(1)
+
+	if _, _, err = q.Get(); err == nil {
+		t.Error("expected error for alias of an expression")
+	}
+}
+
+func TestCursor(t *testing.T) {
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	cursor, err := EncodeCursor(created, 10, "name")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		c    time.Time
+		id   int
+		name string
+	)
+
+	if err = DecodeCursor(cursor, &c, &id, &name); err != nil {
+		t.Fatal(err)
+	}
+	if !c.Equal(created) || id != 10 || name != "name" {
+		t.Errorf("bad decoded cursor. return %v %v %v", c, id, name)
+	}
+
+	if err = DecodeCursor(cursor, &c); err == nil {
+		t.Error("expected error")
+	}
+	if err = DecodeCursor("!", &c); err == nil {
+		t.Error("expected error")
+	}
+}
@@ -0,0 +1,58 @@
+package builder
+
+import "fmt"
+
+type LockStrength string
+
+const (
+	LockUpdate      LockStrength = "UPDATE"
+	LockNoKeyUpdate LockStrength = "NO KEY UPDATE"
+	LockShare       LockStrength = "SHARE"
+	LockKeyShare    LockStrength = "KEY SHARE"
+)
+
+type LockWait int
+
+const (
+	LockWaitDefault LockWait = iota
+	LockNoWait
+	LockSkipLocked
+)
+
+// Lock is a row locking clause of SelectQuery, e.g. FOR UPDATE OF t SKIP LOCKED
+type Lock struct {
+	Strength LockStrength // required
+	Of       []*Table
+	Wait     LockWait
+}
+
+func (l Lock) gen(q query) (string, error) {
+	if l.Strength == "" {
+		return "", fmt.Errorf("lock strength is empty")
+	}
+
+	s := " FOR " + string(l.Strength)
+
+	for i, t := range l.Of {
+		if !q.checkTable(t) {
+			return "", fmt.Errorf("table %s does not exist", t)
+		}
+
+		if i == 0 {
+			s += " OF "
+		} else {
+			s += ", "
+		}
+
+		s += t.alias(q)
+	}
+
+	switch l.Wait {
+	case LockNoWait:
+		s += " NOWAIT"
+	case LockSkipLocked:
+		s += " SKIP LOCKED"
+	}
+
+	return s, nil
+}
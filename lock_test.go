@@ -0,0 +1,69 @@
+package builder
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestLock_gen(t *testing.T) {
+	table1 := NewTable("table1")
+	table2 := NewTable("table2")
+	table3 := NewTable("table3")
+
+	q := NewSelect()
+	q.From(table1, table2)
+
+	tests := []struct {
+		lock Lock
+		s    string
+	}{
+		{Lock{Strength: LockUpdate}, " FOR UPDATE"},
+		{Lock{Strength: LockNoKeyUpdate, Wait: LockNoWait}, " FOR NO KEY UPDATE NOWAIT"},
+		{Lock{Strength: LockShare, Of: []*Table{table1}}, " FOR SHARE OF " + table1.Alias},
+		{Lock{Strength: LockKeyShare, Of: []*Table{table1, table2}, Wait: LockSkipLocked}, " FOR KEY SHARE OF " + table1.Alias + ", " + table2.Alias + " SKIP LOCKED"},
+	}
+
+	for _, tt := range tests {
+		s, err := tt.lock.gen(q)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s != tt.s {
+			t.Errorf("bad returned lock. return %s", s)
+		}
+	}
+
+	if _, err := (Lock{}).gen(q); err == nil {
+		t.Error("expected error")
+	}
+	if _, err := (Lock{Strength: LockUpdate, Of: []*Table{table3}}).gen(q); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestSelectQuery_Lock(t *testing.T) {
+	table := NewTable("jobs")
+
+	q := NewSelect()
+	q.From(table)
+	q.Column(ColumnName{Table: table, Name: "id"})
+	q.Where(WhereIsNull{Table: table, Column: "taken_at"})
+	q.Limit(10)
+	q.Lock(Lock{Strength: LockUpdate, Of: []*Table{table}, Wait: LockSkipLocked})
+
+	sql, binds, err := q.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var l string
+
+	for k := range binds {
+		l = k
+	}
+
+	st := fmt.Sprintf("SELECT %[1]s.id FROM jobs AS %[1]s WHERE %[1]s.taken_at IS NULL LIMIT @%[2]s FOR UPDATE OF %[1]s SKIP LOCKED", table.Alias, l)
+	if sql != st {
+		t.Errorf("bad returned sql. return:\n'%s'\n'%s'", sql, st)
+	}
+}
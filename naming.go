@@ -0,0 +1,161 @@
+package builder
+
+import (
+	"math/rand"
+	"strconv"
+)
+
+type Naming int
+
+const (
+	// NamingDefault uses DefaultNaming
+	NamingDefault Naming = iota
+	// NamingRandom keeps random aliases and bind names, bind names are stable between Get() calls of the same query
+	NamingRandom
+	// NamingSequential names aliases t1, t2, ... and binds column_1, column_2, ... as they are rendered,
+	// so identical query shapes produce identical SQL
+	NamingSequential
+)
+
+// DefaultNaming is used by queries without own Naming
+var DefaultNaming = NamingRandom
+
+// namer of a query holds its naming settings, Get() renders the query with a fresh namer
+// which keeps names of the statement and is the root of every subquery namer
+type namer struct {
+	mode Naming
+	seed int64
+	root *namer
+	rand *rand.Rand
+	// tables collects tables declared in the statement before sequential names are assigned
+	tables map[*Table]bool
+	seq    *sequence
+}
+
+// sequence assigns sequential names of a statement
+type sequence struct {
+	reserved map[string]bool
+	aliases  map[*Table]string
+	tables   int
+	binds    int
+}
+
+// newNamer draws the seed of bind names once, so every Get() of the query renders the same names
+// and different queries do not share them
+func newNamer() namer {
+	return namer{seed: rand.Int63()}
+}
+
+// randStr draws from the source of the running Get(), a query without seed uses the global source
+func (n *namer) randStr() string {
+	r := n.top()
+
+	if r.rand == nil {
+		return randStr()
+	}
+
+	return randStrFrom(r.rand.Intn)
+}
+
+// top returns the namer of the running Get()
+func (n *namer) top() *namer {
+	if n.root != nil {
+		return n.root
+	}
+
+	return n
+}
+
+// declare registers a table rendered in FROM, JOIN or as query target
+func (n *namer) declare(t *Table) {
+	r := n.top()
+
+	if r.tables != nil {
+		r.tables[t] = true
+	}
+}
+
+// alias returns the alias of t, sequential naming assigns the next free tN on first use
+func (n *namer) alias(t *Table) string {
+	r := n.top()
+
+	if r.seq == nil || !t.auto {
+		return t.Alias
+	}
+
+	if a, ok := r.seq.aliases[t]; ok {
+		return a
+	}
+
+	for {
+		r.seq.tables++
+
+		if a := "t" + strconv.Itoa(r.seq.tables); !r.seq.reserved[a] {
+			r.seq.aliases[t] = a
+
+			return a
+		}
+	}
+}
+
+// bind returns a new bind name starting with prefix
+func (n *namer) bind(prefix string) string {
+	r := n.top()
+
+	if r.seq == nil {
+		return prefix + "_" + r.randStr()
+	}
+
+	r.seq.binds++
+
+	return prefix + "_" + strconv.Itoa(r.seq.binds)
+}
+
+// get renders the query with build, which copies the query under root.
+// Sequential naming renders twice, the first pass finds names of tables and user set aliases,
+// which generated aliases skip.
+func (n *namer) get(root *namer, build func(root *namer) (string, map[string]any, error)) (string, map[string]any, error) {
+	if root != nil {
+		return build(root)
+	}
+
+	r := &namer{mode: n.mode, seed: n.seed}
+
+	if r.seed != 0 {
+		r.rand = rand.New(rand.NewSource(r.seed))
+	}
+
+	mode := r.mode
+	if mode == NamingDefault {
+		mode = DefaultNaming
+	}
+
+	if mode != NamingSequential {
+		return build(r)
+	}
+
+	r.tables = make(map[*Table]bool)
+
+	if _, _, err := build(r); err != nil {
+		return "", nil, err
+	}
+
+	r.seq = &sequence{
+		reserved: make(map[string]bool, len(r.tables)),
+		aliases:  make(map[*Table]string, len(r.tables)),
+	}
+
+	for t := range r.tables {
+		if !t.auto {
+			r.seq.reserved[t.Alias] = true
+		}
+
+		if t.Name != "" {
+			r.seq.reserved[t.Name] = true
+		}
+	}
+
+	r.tables = nil
+
+	return build(r)
+}
@@ -0,0 +1,196 @@
+package builder
+
+import (
+	"testing"
+)
+
+func namingQuery() *SelectQuery {
+	table1 := NewTable("table1")
+	table2 := NewTable("table2")
+	table3 := NewTable("table3")
+
+	sub := NewSelect()
+	sub.From(table3)
+	sub.Column(ColumnName{Table: table3, Name: "id"})
+	sub.Where(WhereEq{Table: table3, Column: "id", Value: 7})
+
+	table4 := NewTableSub(sub)
+
+	q := NewSelect()
+	q.From(table1, table4)
+	q.Column(ColumnName{Table: table1, Name: "id"}, ColumnName{Table: table2, Name: "col"})
+	q.LeftJoin(table2, OnEq{Table1: table1, Table2: table2, Column1: "id", Column2: "table_id"})
+	q.Where(WhereAnd{List: []Where{
+		WhereEq{Table: table1, Column: "id", Value: 1},
+		WhereEqColumn{Table1: table1, Column1: "id", Table2: table4, Column2: "id"},
+	}})
+	q.Limit(10)
+
+	return q
+}
+
+func TestNaming_Sequential(t *testing.T) {
+	q := namingQuery().Naming(NamingSequential)
+
+	sql, binds, err := q.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	st := "SELECT t1.id, t2.col FROM table1 AS t1, (SELECT t3.id FROM table3 AS t3 WHERE t3.id = @id_1) AS t4 LEFT JOIN table2 AS t2 ON t1.id = t2.table_id WHERE (t1.id = @id_2 AND t1.id = t4.id) LIMIT @limit_3"
+	if sql != st {
+		t.Errorf("bad returned sql. return:\n'%s'\n'%s'", sql, st)
+	}
+	if len(binds) != 3 || binds["id_1"] != 7 || binds["id_2"] != 1 || binds["limit_3"] != 10 {
+		t.Errorf("bad returned binds. return %v", binds)
+	}
+
+	sql2, _, err := namingQuery().Naming(NamingSequential).Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sql2 != sql {
+		t.Errorf("identical queries should have identical sql:\n'%s'\n'%s'", sql, sql2)
+	}
+}
+
+func TestNaming_Default(t *testing.T) {
+	DefaultNaming = NamingSequential
+	defer func() {
+		DefaultNaming = NamingRandom
+	}()
+
+	table := NewTable("table")
+
+	q := NewUpdate(table)
+	q.Set("col", 1)
+	q.Where(WhereEq{Table: table, Column: "id", Value: 2})
+
+	sql, binds, err := q.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sql != "UPDATE \"table\" AS t1 SET col = @col_1 WHERE t1.id = @id_2" {
+		t.Errorf("bad returned sql. return %s", sql)
+	}
+	if binds["col_1"] != 1 || binds["id_2"] != 2 {
+		t.Errorf("bad returned binds. return %v", binds)
+	}
+
+	q.Naming(NamingRandom)
+
+	sql, _, err = q.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sql == "UPDATE table AS t1 SET col = @col_1 WHERE t1.id = @id_2" {
+		t.Errorf("random naming should keep random names")
+	}
+}
+
+func TestNaming_Table(t *testing.T) {
+	table := &Table{Name: "table", Alias: "t"}
+
+	q := NewDelete(table)
+	q.Naming(NamingSequential)
+	q.Where(WhereEq{Table: table, Column: "id", Value: 1})
+
+	sql, _, err := q.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sql != "DELETE FROM \"table\" AS t WHERE t.id = @id_1" {
+		t.Errorf("bad returned sql. return %s", sql)
+	}
+}
+
+func TestNaming_Collision(t *testing.T) {
+	table1 := &Table{Name: "t1", Alias: "t1"}
+	table2 := NewTable("b")
+	table3 := NewTable("t2")
+
+	q := NewSelect().Naming(NamingSequential)
+	q.From(table1, table2, table3)
+	q.Column(ColumnName{Table: table2, Name: "id"})
+
+	sql, _, err := q.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sql != "SELECT t3.id FROM t1 AS t1, b AS t3, t2 AS t4" {
+		t.Errorf("bad returned sql. return %s", sql)
+	}
+}
+
+func TestNaming_RandomBinds(t *testing.T) {
+	table := NewTable("table")
+
+	q1 := NewSelect()
+	q1.From(table)
+	q1.Column(ColumnName{Table: table, Name: "id"})
+	q1.Where(WhereEq{Table: table, Column: "id", Value: 1})
+
+	q2 := NewSelect()
+	q2.From(table)
+	q2.Column(ColumnName{Table: table, Name: "id"})
+	q2.Where(WhereEq{Table: table, Column: "id", Value: 2})
+
+	sql1, binds1, err := q1.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sql, _, err := q1.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sql != sql1 {
+		t.Errorf("same query should render the same sql:\n'%s'\n'%s'", sql1, sql)
+	}
+
+	sql2, binds2, err := q2.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sql2 == sql1 {
+		t.Errorf("different queries should not share bind names. return %s", sql2)
+	}
+
+	for k := range binds1 {
+		if _, ok := binds2[k]; ok {
+			t.Errorf("bind %s is used by both queries", k)
+		}
+	}
+}
+
+func TestNaming_Literal(t *testing.T) {
+	table := NewTable("items")
+
+	q := NewSelect().Naming(NamingSequential)
+	q.From(table)
+	q.Column(ColumnName{Table: table, Name: "id"}, ColumnValue{Value: table.Alias, Alias: "x"})
+	q.Where(WhereFullText{Table: table, Column: "body", Language: table.Alias + " @x_1", Value: "v"})
+
+	sql, binds, err := q.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	st := "SELECT t1.id, @x_1 AS x FROM items AS t1 WHERE to_tsvector(" + QuoteLiteral(table.Alias+" @x_1") + ", t1.body) @@ plainto_tsquery(@body_2)"
+	if sql != st {
+		t.Errorf("bad returned sql. return:\n'%s'\n'%s'", sql, st)
+	}
+	if len(binds) != 2 || binds["x_1"] != table.Alias || binds["body_2"] != "v" {
+		t.Errorf("bad returned binds. return %v", binds)
+	}
+
+	sql, args, err := q.GetPositional()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	st = "SELECT t1.id, $1 AS x FROM items AS t1 WHERE to_tsvector(" + QuoteLiteral(table.Alias+" @x_1") + ", t1.body) @@ plainto_tsquery($2)"
+	if sql != st || len(args) != 2 {
+		t.Errorf("bad returned positional sql. return %s %v", sql, args)
+	}
+}
@@ -0,0 +1,235 @@
+// Package pgxexec runs queries of the builder package with pgx and scans rows into structs by the db tag
+package pgxexec
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/xloss/go-builder/dbtag"
+)
+
+// Querier is implemented by *pgx.Conn, *pgxpool.Pool and pgx.Tx
+type Querier interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+// Query is any query of the builder package
+type Query interface {
+	Get() (string, map[string]any, error)
+}
+
+// Exec runs a query which returns no rows
+func Exec(ctx context.Context, db Querier, q Query) (pgconn.CommandTag, error) {
+	sql, binds, err := q.Get()
+	if err != nil {
+		return pgconn.CommandTag{}, err
+	}
+
+	return db.Exec(ctx, sql, pgx.NamedArgs(binds))
+}
+
+func query(ctx context.Context, db Querier, q Query) (pgx.Rows, error) {
+	sql, binds, err := q.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	return db.Query(ctx, sql, pgx.NamedArgs(binds))
+}
+
+// One returns the only row, pgx.ErrNoRows or pgx.ErrTooManyRows otherwise
+func One[T any](ctx context.Context, db Querier, q Query) (T, error) {
+	var zero T
+
+	rows, err := query(ctx, db, q)
+	if err != nil {
+		return zero, err
+	}
+	defer rows.Close()
+
+	s, err := newScanner[T](rows.FieldDescriptions())
+	if err != nil {
+		return zero, err
+	}
+
+	if !rows.Next() {
+		if err = rows.Err(); err != nil {
+			return zero, err
+		}
+
+		return zero, pgx.ErrNoRows
+	}
+
+	v, err := s.scan(rows)
+	if err != nil {
+		return zero, err
+	}
+
+	if rows.Next() {
+		return zero, pgx.ErrTooManyRows
+	}
+
+	if err = rows.Err(); err != nil {
+		return zero, err
+	}
+
+	return v, nil
+}
+
+// All returns all rows
+func All[T any](ctx context.Context, db Querier, q Query) ([]T, error) {
+	var (
+		list []T
+		err  error
+	)
+
+	Iter[T](ctx, db, q)(func(v T, e error) bool {
+		if e != nil {
+			err = e
+
+			return false
+		}
+
+		list = append(list, v)
+
+		return true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// Iter streams rows, iteration stops after the first error. With Go 1.23 it can be used with range:
+//
+//	for user, err := range pgxexec.Iter[User](ctx, db, q) {
+//	}
+func Iter[T any](ctx context.Context, db Querier, q Query) func(yield func(T, error) bool) {
+	return func(yield func(T, error) bool) {
+		var zero T
+
+		rows, err := query(ctx, db, q)
+		if err != nil {
+			yield(zero, err)
+
+			return
+		}
+		defer rows.Close()
+
+		s, err := newScanner[T](rows.FieldDescriptions())
+		if err != nil {
+			yield(zero, err)
+
+			return
+		}
+
+		for rows.Next() {
+			v, err := s.scan(rows)
+			if !yield(v, err) || err != nil {
+				return
+			}
+		}
+
+		if err = rows.Err(); err != nil {
+			yield(zero, err)
+		}
+	}
+}
+
+// scanner maps result columns to fields of T by the db tag, T without db fields is scanned from a single column
+type scanner[T any] struct {
+	fields []dbtag.Field
+	ptr    bool
+}
+
+func newScanner[T any](columns []pgconn.FieldDescription) (*scanner[T], error) {
+	var (
+		s = &scanner[T]{}
+		t = reflect.TypeFor[T]()
+	)
+
+	if t.Kind() == reflect.Pointer && t.Elem().Kind() == reflect.Struct {
+		s.ptr = true
+		t = t.Elem()
+	}
+
+	var fields []dbtag.Field
+
+	if t.Kind() == reflect.Struct {
+		fields = dbtag.Fields(t)
+	}
+
+	if len(fields) == 0 {
+		if len(columns) != 1 {
+			return nil, fmt.Errorf("%s has no db fields, expected 1 column, got %d", t, len(columns))
+		}
+
+		return &scanner[T]{}, nil
+	}
+
+	byColumn := make(map[string]dbtag.Field, len(fields))
+
+	for _, f := range fields {
+		byColumn[f.Column] = f
+	}
+
+	s.fields = make([]dbtag.Field, len(columns))
+
+	for i, c := range columns {
+		f, ok := byColumn[c.Name]
+		if !ok {
+			return nil, fmt.Errorf("column %s has no db field in %s", c.Name, t)
+		}
+
+		s.fields[i] = f
+	}
+
+	return s, nil
+}
+
+func (s *scanner[T]) scan(rows pgx.Rows) (T, error) {
+	var v T
+
+	if s.fields == nil {
+		if err := rows.Scan(&v); err != nil {
+			return v, err
+		}
+
+		return v, nil
+	}
+
+	rv := reflect.ValueOf(&v).Elem()
+
+	if s.ptr {
+		rv.Set(reflect.New(rv.Type().Elem()))
+		rv = rv.Elem()
+	}
+
+	dest := make([]any, len(s.fields))
+
+	for i, f := range s.fields {
+		p, ok := f.Addr(rv)
+		if !ok {
+			var zero T
+
+			return zero, fmt.Errorf("field of column %s cannot be set", f.Column)
+		}
+
+		dest[i] = p
+	}
+
+	if err := rows.Scan(dest...); err != nil {
+		var zero T
+
+		return zero, err
+	}
+
+	return v, nil
+}
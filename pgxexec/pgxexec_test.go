@@ -0,0 +1,268 @@
+package pgxexec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	builder "github.com/xloss/go-builder"
+)
+
+type fakeQuerier struct {
+	columns []string
+	rows    [][]any
+	err     error
+	sql     string
+	args    []any
+}
+
+func (f *fakeQuerier) Exec(_ context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	f.sql, f.args = sql, args
+
+	return pgconn.NewCommandTag("DELETE 1"), f.err
+}
+
+func (f *fakeQuerier) Query(_ context.Context, sql string, args ...any) (pgx.Rows, error) {
+	f.sql, f.args = sql, args
+
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	return &fakeRows{columns: f.columns, rows: f.rows, i: -1}, nil
+}
+
+type fakeRows struct {
+	columns []string
+	rows    [][]any
+	i       int
+	closed  bool
+}
+
+func (r *fakeRows) Close() {
+	r.closed = true
+}
+
+func (r *fakeRows) Err() error {
+	return nil
+}
+
+func (r *fakeRows) CommandTag() pgconn.CommandTag {
+	return pgconn.NewCommandTag("SELECT " + fmt.Sprint(len(r.rows)))
+}
+
+func (r *fakeRows) FieldDescriptions() []pgconn.FieldDescription {
+	list := make([]pgconn.FieldDescription, len(r.columns))
+
+	for i, c := range r.columns {
+		list[i] = pgconn.FieldDescription{Name: c}
+	}
+
+	return list
+}
+
+func (r *fakeRows) Next() bool {
+	if r.closed || r.i+1 >= len(r.rows) {
+		return false
+	}
+
+	r.i++
+
+	return true
+}
+
+func (r *fakeRows) Scan(dest ...any) error {
+	if len(dest) != len(r.columns) {
+		return fmt.Errorf("expected %d destinations, got %d", len(r.columns), len(dest))
+	}
+
+	for i, d := range dest {
+		v := reflect.ValueOf(r.rows[r.i][i])
+		t := reflect.ValueOf(d).Elem()
+
+		if !v.Type().AssignableTo(t.Type()) {
+			return fmt.Errorf("cannot scan %s into %s", v.Type(), t.Type())
+		}
+
+		t.Set(v)
+	}
+
+	return nil
+}
+
+func (r *fakeRows) Values() ([]any, error) {
+	return r.rows[r.i], nil
+}
+
+func (r *fakeRows) RawValues() [][]byte {
+	return nil
+}
+
+func (r *fakeRows) Conn() *pgx.Conn {
+	return nil
+}
+
+type Audit struct {
+	CreatedAt string `db:"created_at"`
+}
+
+type user struct {
+	ID   int    `db:"id,pk"`
+	Name string `db:"name"`
+	*Audit
+}
+
+func selectUsers() *builder.SelectQuery {
+	table := builder.NewTable("users")
+
+	return builder.NewSelect().
+		Naming(builder.NamingSequential).
+		From(table).
+		Column(
+			builder.ColumnName{Table: table, Name: "id"},
+			builder.ColumnName{Table: table, Name: "full_name", Alias: "name"},
+		).
+		Where(builder.WhereEq{Table: table, Column: "active", Value: true})
+}
+
+func TestOne(t *testing.T) {
+	db := &fakeQuerier{columns: []string{"id", "name"}, rows: [][]any{{1, "name1"}}}
+
+	u, err := One[user](context.Background(), db, selectUsers())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if u.ID != 1 || u.Name != "name1" {
+		t.Errorf("bad scanned row. return %+v", u)
+	}
+	if db.sql != "SELECT t1.id, t1.full_name AS name FROM users AS t1 WHERE t1.active = @active_1" {
+		t.Errorf("bad sql. return %s", db.sql)
+	}
+	if len(db.args) != 1 || !reflect.DeepEqual(db.args[0], pgx.NamedArgs{"active_1": true}) {
+		t.Errorf("bad args. return %#v", db.args)
+	}
+
+	db.rows = nil
+
+	if _, err = One[user](context.Background(), db, selectUsers()); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("expected no rows error, got %v", err)
+	}
+
+	db.rows = [][]any{{1, "name1"}, {2, "name2"}}
+
+	if _, err = One[user](context.Background(), db, selectUsers()); !errors.Is(err, pgx.ErrTooManyRows) {
+		t.Errorf("expected too many rows error, got %v", err)
+	}
+
+	db.columns = []string{"id", "email"}
+
+	if _, err = One[user](context.Background(), db, selectUsers()); err == nil {
+		t.Error("expected error for column without field")
+	}
+}
+
+func TestOne_scalar(t *testing.T) {
+	db := &fakeQuerier{columns: []string{"c"}, rows: [][]any{{5}}}
+
+	c, err := One[int](context.Background(), db, selectUsers())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c != 5 {
+		t.Errorf("bad scanned value. return %d", c)
+	}
+
+	db.columns = []string{"c", "d"}
+	db.rows = [][]any{{5, 6}}
+
+	if _, err = One[int](context.Background(), db, selectUsers()); err == nil {
+		t.Error("expected error for several columns")
+	}
+}
+
+func TestAll(t *testing.T) {
+	db := &fakeQuerier{
+		columns: []string{"id", "name", "created_at"},
+		rows:    [][]any{{1, "name1", "today"}, {2, "name2", "yesterday"}},
+	}
+
+	list, err := All[*user](context.Background(), db, selectUsers())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []*user{
+		{ID: 1, Name: "name1", Audit: &Audit{CreatedAt: "today"}},
+		{ID: 2, Name: "name2", Audit: &Audit{CreatedAt: "yesterday"}},
+	}
+
+	if !reflect.DeepEqual(list, expected) {
+		t.Errorf("bad scanned rows. return %+v", list)
+	}
+
+	db.rows = [][]any{{1, "name1", "today"}, {"2", "name2", "yesterday"}}
+
+	if _, err = All[user](context.Background(), db, selectUsers()); err == nil {
+		t.Error("expected scan error")
+	}
+
+	if _, err = All[user](context.Background(), db, builder.NewSelect()); err == nil {
+		t.Error("expected error of query")
+	}
+
+	db.err = errors.New("connection refused")
+
+	if _, err = All[user](context.Background(), db, selectUsers()); !errors.Is(err, db.err) {
+		t.Errorf("expected querier error, got %v", err)
+	}
+}
+
+func TestIter(t *testing.T) {
+	db := &fakeQuerier{columns: []string{"id", "name"}, rows: [][]any{{1, "name1"}, {2, "name2"}, {3, "name3"}}}
+
+	var ids []int
+
+	Iter[user](context.Background(), db, selectUsers())(func(u user, err error) bool {
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ids = append(ids, u.ID)
+
+		return len(ids) < 2
+	})
+
+	if !reflect.DeepEqual(ids, []int{1, 2}) {
+		t.Errorf("iteration should stop after 2 rows. return %v", ids)
+	}
+}
+
+func TestExec(t *testing.T) {
+	table := builder.NewTable("users")
+	db := &fakeQuerier{}
+
+	tag, err := Exec(context.Background(), db, builder.NewDelete(table).Naming(builder.NamingSequential).WherePK(user{ID: 3}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if tag.RowsAffected() != 1 {
+		t.Errorf("bad command tag. return %s", tag)
+	}
+	if db.sql != "DELETE FROM users AS t1 WHERE t1.id = @id_1" {
+		t.Errorf("bad sql. return %s", db.sql)
+	}
+	if !reflect.DeepEqual(db.args, []any{pgx.NamedArgs{"id_1": 3}}) {
+		t.Errorf("bad args. return %#v", db.args)
+	}
+
+	if _, err = Exec(context.Background(), db, builder.NewDelete(table)); err == nil {
+		t.Error("expected error of query")
+	}
+}
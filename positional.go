@@ -0,0 +1,46 @@
+package builder
+
+import (
+	"regexp"
+	"strconv"
+)
+
+// positionalToken matches a bind, string literals and quoted identifiers are matched to be skipped
+var positionalToken = regexp.MustCompile(`'(?:[^']|'')*'|"(?:[^"]|"")*"|@[A-Za-z_][A-Za-z0-9_]*`)
+
+// getPositional renders q with $1..$n placeholders, a bind used several times keeps its position
+func getPositional(q query) (string, []any, error) {
+	sql, binds, err := q.Get()
+	if err != nil {
+		return "", nil, err
+	}
+
+	var (
+		positions = make(map[string]int, len(binds))
+		args      = make([]any, 0, len(binds))
+	)
+
+	sql = positionalToken.ReplaceAllStringFunc(sql, func(s string) string {
+		if s[0] != '@' {
+			return s
+		}
+
+		key := s[1:]
+
+		v, ok := binds[key]
+		if !ok {
+			return s
+		}
+
+		pos, ok := positions[key]
+		if !ok {
+			args = append(args, v)
+			pos = len(args)
+			positions[key] = pos
+		}
+
+		return "$" + strconv.Itoa(pos)
+	})
+
+	return sql, args, nil
+}
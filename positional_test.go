@@ -0,0 +1,65 @@
+package builder
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestGetPositional(t *testing.T) {
+	table := NewTable("table")
+
+	q := NewSelect()
+	q.From(table)
+	q.Column(ColumnName{Table: table, Name: "id"})
+	q.Where(WhereEq{Table: table, Column: "id", Value: 5})
+	q.Order(Order{Table: table, Column: "created_at"}, Order{Table: table, Column: "id", Desc: true})
+	q.After("2024-01-01", 100)
+	q.Limit(10)
+
+	sql, args, err := q.GetPositional()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	st := fmt.Sprintf("SELECT %[1]s.id FROM \"table\" AS %[1]s WHERE %[1]s.id = $1 AND (%[1]s.created_at > $2 OR (%[1]s.created_at = $2 AND %[1]s.id < $3)) ORDER BY %[1]s.created_at, %[1]s.id DESC LIMIT $4", table.Alias)
+	if sql != st {
+		t.Errorf("bad returned sql. return:\n'%s'\n'%s'", sql, st)
+	}
+
+	if len(args) != 4 || args[0] != 5 || args[1] != "2024-01-01" || args[2] != 100 || args[3] != 10 {
+		t.Errorf("bad returned args. return %v", args)
+	}
+
+	q.Column(ColumnName{Table: NewTable("table2"), Name: "id"})
+
+	if _, _, err = q.GetPositional(); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestGetPositional_Queries(t *testing.T) {
+	table := NewTable("table")
+
+	i := NewInsert(table)
+	i.Value("col1", 1)
+	i.Value("col2", "a")
+
+	sql, args, err := i.GetPositional()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sql != "INSERT INTO \"table\" AS "+table.Alias+" (col1, col2) VALUES ($1, $2)" || len(args) != 2 || args[0] != 1 || args[1] != "a" {
+		t.Errorf("bad returned sql. return %s %v", sql, args)
+	}
+
+	d := NewDelete(table)
+	d.Where(WhereIn{Table: table, Column: "id", Values: []int{1, 2}})
+
+	sql, args, err = d.GetPositional()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sql != "DELETE FROM \"table\" AS "+table.Alias+" WHERE "+table.Alias+".id = ANY($1)" || len(args) != 1 {
+		t.Errorf("bad returned sql. return %s %v", sql, args)
+	}
+}
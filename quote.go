@@ -0,0 +1,138 @@
+package builder
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// AlwaysQuote quotes every table, alias and column name. By default only lowercase
+// identifiers which are not reserved keywords are left bare.
+var AlwaysQuote = false
+
+var (
+	plainIdentifier = regexp.MustCompile(`^[a-z_][a-z0-9_$]*$`)
+	bindUnsafe      = regexp.MustCompile(`[^A-Za-z0-9_]`)
+)
+
+// reservedKeywords are PostgreSQL keywords which cannot be a table or column name without quotes
+var reservedKeywords = map[string]bool{
+	"all": true, "analyse": true, "analyze": true, "and": true, "any": true, "array": true, "as": true,
+	"asc": true, "asymmetric": true, "authorization": true, "binary": true, "both": true, "case": true,
+	"cast": true, "check": true, "collate": true, "collation": true, "column": true, "concurrently": true,
+	"constraint": true, "create": true, "cross": true, "current_catalog": true, "current_date": true,
+	"current_role": true, "current_schema": true, "current_time": true, "current_timestamp": true,
+	"current_user": true, "default": true, "deferrable": true, "desc": true, "distinct": true, "do": true,
+	"else": true, "end": true, "except": true, "false": true, "fetch": true, "for": true, "foreign": true,
+	"freeze": true, "from": true, "full": true, "grant": true, "group": true, "having": true, "ilike": true,
+	"in": true, "initially": true, "inner": true, "intersect": true, "into": true, "is": true, "isnull": true,
+	"join": true, "lateral": true, "leading": true, "left": true, "like": true, "limit": true,
+	"localtime": true, "localtimestamp": true, "natural": true, "not": true, "notnull": true, "null": true,
+	"offset": true, "on": true, "only": true, "or": true, "order": true, "outer": true, "overlaps": true,
+	"placing": true, "primary": true, "references": true, "returning": true, "right": true, "select": true,
+	"session_user": true, "similar": true, "some": true, "symmetric": true, "system_user": true,
+	"table": true, "tablesample": true, "then": true, "to": true, "trailing": true, "true": true,
+	"union": true, "unique": true, "user": true, "using": true, "variadic": true, "verbose": true,
+	"when": true, "where": true, "window": true, "with": true,
+}
+
+// QuoteIdentifier quotes a table, alias or column name
+func QuoteIdentifier(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+}
+
+// QuoteLiteral quotes a string literal the same way as PostgreSQL quote_literal
+func QuoteLiteral(s string) string {
+	if strings.Contains(s, `\`) {
+		return `E'` + strings.ReplaceAll(strings.ReplaceAll(s, `\`, `\\`), `'`, `''`) + `'`
+	}
+
+	return `'` + strings.ReplaceAll(s, `'`, `''`) + `'`
+}
+
+func quoteIdent(s string) string {
+	if !AlwaysQuote && plainIdentifier.MatchString(s) && !reservedKeywords[s] {
+		return s
+	}
+
+	return QuoteIdentifier(s)
+}
+
+func quoteIdents(list []string) string {
+	s := make([]string, len(list))
+
+	for i, v := range list {
+		s[i] = quoteIdent(v)
+	}
+
+	return strings.Join(s, ", ")
+}
+
+// literal renders booleans, integers and finite floats as SQL literals, other values are not supported
+func literal(v any) (string, bool) {
+	switch v := v.(type) {
+	case bool:
+		return strconv.FormatBool(v), true
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprintf("%d", v), true
+	case float32:
+		return floatLiteral(float64(v), 32)
+	case float64:
+		return floatLiteral(v, 64)
+	default:
+		return "", false
+	}
+}
+
+// floatLiteral renders finite floats, NaN and infinities have no numeric literal
+func floatLiteral(v float64, bitSize int) (string, bool) {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return "", false
+	}
+
+	return strconv.FormatFloat(v, 'g', -1, bitSize), true
+}
+
+// literalOrBind renders v as a literal when it keeps its type as a literal and as a bind otherwise,
+// so strings are always bound
+func literalOrBind(q query, prefix string, v any) string {
+	if s, ok := literal(v); ok {
+		return s
+	}
+
+	tag := bindTag(q, prefix)
+
+	q.addBind(tag, v)
+
+	return "@" + tag
+}
+
+// bindTag makes a unique bind name from prefix, names drawn while rendering repeat on every Get()
+func bindTag(q query, prefix string) string {
+	prefix = bindUnsafe.ReplaceAllString(prefix, "")
+	if prefix == "" {
+		prefix = "value"
+	}
+
+	if q == nil {
+		return prefix + "_" + randStr()
+	}
+
+	return q.names().bind(prefix)
+}
+
+// ref renders a column of the table as alias.column
+func (t *Table) ref(q query, column string) string {
+	if column == "*" {
+		return t.alias(q) + ".*"
+	}
+
+	return t.alias(q) + "." + quoteIdent(column)
+}
+
+// alias renders the alias of the table in the running Get()
+func (t *Table) alias(q query) string {
+	return quoteIdent(q.names().alias(t))
+}
@@ -0,0 +1,161 @@
+package builder
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestQuoteIdentifier(t *testing.T) {
+	if s := QuoteIdentifier(`col`); s != `"col"` {
+		t.Errorf("bad quoted identifier. return %s", s)
+	}
+	if s := QuoteIdentifier(`a"b`); s != `"a""b"` {
+		t.Errorf("bad quoted identifier. return %s", s)
+	}
+
+	if s := quoteIdent("col_1"); s != "col_1" {
+		t.Errorf("plain identifier should not be quoted. return %s", s)
+	}
+	if s := quoteIdent("my col"); s != `"my col"` {
+		t.Errorf("bad quoted identifier. return %s", s)
+	}
+	if s := quoteIdent(`id; DROP TABLE users; --`); s != `"id; DROP TABLE users; --"` {
+		t.Errorf("bad quoted identifier. return %s", s)
+	}
+
+	for _, v := range []string{"order", "user", "group", "table", "Order", "userId"} {
+		if s := quoteIdent(v); s != `"`+v+`"` {
+			t.Errorf("reserved or mixed case identifier should be quoted. return %s", s)
+		}
+	}
+
+	q := NewInsert(NewTable("user")).Naming(NamingSequential)
+	q.Value("order", 1).Value("name", 2)
+
+	sql, _, err := q.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sql != `INSERT INTO "user" AS t1 ("order", name) VALUES (@order_1, @name_2)` {
+		t.Errorf("bad returned sql. return %s", sql)
+	}
+
+	AlwaysQuote = true
+	defer func() {
+		AlwaysQuote = false
+	}()
+
+	if s := quoteIdent("col_1"); s != `"col_1"` {
+		t.Errorf("identifier should be quoted. return %s", s)
+	}
+}
+
+func TestQuoteLiteral(t *testing.T) {
+	if s := QuoteLiteral("text"); s != "'text'" {
+		t.Errorf("bad quoted literal. return %s", s)
+	}
+	if s := QuoteLiteral("it's"); s != "'it''s'" {
+		t.Errorf("bad quoted literal. return %s", s)
+	}
+	if s := QuoteLiteral(`a\'b`); s != `E'a\\''b'` {
+		t.Errorf("bad quoted literal. return %s", s)
+	}
+}
+
+func TestLiteral(t *testing.T) {
+	tests := []struct {
+		v  any
+		s  string
+		ok bool
+	}{
+		{"a'b", "", false},
+		{10, "10", true},
+		{int64(-3), "-3", true},
+		{uint8(7), "7", true},
+		{1.5, "1.5", true},
+		{float32(0.25), "0.25", true},
+		{true, "true", true},
+		{math.NaN(), "", false},
+		{float32(math.Inf(-1)), "", false},
+		{[]int{1}, "", false},
+	}
+
+	for _, tt := range tests {
+		s, ok := literal(tt.v)
+		if s != tt.s || ok != tt.ok {
+			t.Errorf("bad literal for %v. return %s %v", tt.v, s, ok)
+		}
+	}
+}
+
+func TestBindTag(t *testing.T) {
+	tag := bindTag(nil, `col"; --`)
+
+	if !strings.HasPrefix(tag, "col_") || len(tag) != len("col_")+randStrLen {
+		t.Errorf("bad bind tag. return %s", tag)
+	}
+
+	tag = bindTag(nil, "")
+
+	if !strings.HasPrefix(tag, "value_") {
+		t.Errorf("bad bind tag. return %s", tag)
+	}
+}
+
+func TestQuote_Values(t *testing.T) {
+	table := &Table{Name: "items", Alias: "i"}
+	q := NewSelect().Naming(NamingSequential)
+	q.From(table)
+	q.Column(
+		ColumnValue{Value: "'; DROP TABLE users; --", Alias: "a1"},
+		ColumnCoalesce{Table: table, Name: "col", Alias: "a2", Default: "it's"},
+		ColumnValue{Value: math.NaN(), Alias: "a3"},
+		ColumnValue{Value: math.Inf(1), Alias: "a4"},
+		ColumnValue{Value: []int{1, 2}, Alias: "a5"},
+		ColumnValue{Value: 1.5, Alias: "a6"},
+	)
+
+	sql, binds, err := q.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sql != "SELECT @a1_1 AS a1, COALESCE(i.col, @col_2) AS a2, @a3_3 AS a3, @a4_4 AS a4, @a5_5 AS a5, 1.5 AS a6 FROM items AS i" {
+		t.Errorf("bad returned sql. return %s", sql)
+	}
+	if len(binds) != 5 || binds["a1_1"] != "'; DROP TABLE users; --" || binds["col_2"] != "it's" || !math.IsInf(binds["a4_4"].(float64), 1) {
+		t.Errorf("bad returned binds. return %v", binds)
+	}
+
+	sql, _, err = WhereFullText{Table: table, Column: "col", Language: "english'); --", Value: "v"}.gen(q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(sql, "to_tsvector('english''); --', ") {
+		t.Errorf("bad returned where. return %s", sql)
+	}
+}
+
+func TestQuote_Always(t *testing.T) {
+	AlwaysQuote = true
+	defer func() {
+		AlwaysQuote = false
+	}()
+
+	table := NewTable("user")
+
+	q := NewSelect()
+	q.From(table)
+	q.Column(ColumnName{Table: table, Name: "id", Alias: "a1"})
+	q.Order(Order{Column: "a1"})
+
+	sql, _, err := q.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	st := `SELECT "` + table.Alias + `"."id" AS "a1" FROM "user" AS "` + table.Alias + `" ORDER BY "a1"`
+	if sql != st {
+		t.Errorf("bad returned sql. return:\n'%s'\n'%s'", sql, st)
+	}
+}
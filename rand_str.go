@@ -5,6 +5,10 @@ import "math/rand"
 const randStrLen = 10
 
 func randStr() string {
+	return randStrFrom(rand.Intn)
+}
+
+func randStrFrom(intn func(n int) int) string {
 	var (
 		letterRunes  = []rune("abcdefghijklmnopqrstuvwxyz")
 		letterLength = len(letterRunes)
@@ -12,7 +16,7 @@ func randStr() string {
 	)
 
 	for i := 0; i < randStrLen; i++ {
-		runeString[i] = letterRunes[rand.Intn(letterLength)]
+		runeString[i] = letterRunes[intn(letterLength)]
 	}
 
 	return string(runeString)
@@ -0,0 +1,25 @@
+package builder
+
+// scope links a subquery to the query it is nested in
+type scope struct {
+	parent query
+}
+
+func (s *scope) outer() query {
+	return s.parent
+}
+
+// checkOuter looks for the table in the enclosing queries
+func (s *scope) checkOuter(table *Table) bool {
+	return s.parent != nil && s.parent.checkTable(table)
+}
+
+// getSub renders a correlated subquery, its tables are resolved in child first and then in parent
+func getSub(parent, child query) (string, map[string]any, error) {
+	return child.render(parent.names().top(), parent)
+}
+
+// getDerived renders child in FROM, WITH or as a compound part, where tables of parent are not visible
+func getDerived(parent, child query) (string, map[string]any, error) {
+	return child.render(parent.names().top(), parent.outer())
+}
@@ -0,0 +1,155 @@
+package builder
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestScope_checkOuter(t *testing.T) {
+	table1 := NewTable("table1")
+	table2 := NewTable("table2")
+
+	s := scope{}
+
+	if s.checkOuter(table1) {
+		t.Errorf("s.checkOuter() returned true")
+	}
+
+	q := NewSelect()
+	q.From(table1)
+	s.parent = q
+
+	if !s.checkOuter(table1) {
+		t.Errorf("s.checkOuter() returned false")
+	}
+	if s.checkOuter(table2) {
+		t.Errorf("s.checkOuter() returned true")
+	}
+	if s.outer() != q {
+		t.Errorf("s.outer() should return parent")
+	}
+}
+
+func TestGetSub_correlated(t *testing.T) {
+	table1 := NewTable("table1")
+	table2 := NewTable("table2")
+	table3 := NewTable("table3")
+	table4 := NewTable("table4")
+
+	q3 := NewSelect()
+	q3.From(table3)
+	q3.Where(WhereEqColumn{Table1: table1, Column1: "id", Table2: table3, Column2: "table1_id"})
+
+	q2 := NewSelect()
+	q2.From(table2)
+	q2.Where(WhereAnd{List: []Where{
+		WhereEqColumn{Table1: table1, Column1: "id", Table2: table2, Column2: "table1_id"},
+		WhereExists{Query: q3},
+	}})
+
+	q := NewSelect()
+	q.From(table1)
+	q.Column(ColumnName{Table: table1, Name: "id"})
+	q.Where(WhereExists{Query: q2})
+
+	sql, _, err := q.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	st := fmt.Sprintf("SELECT %[1]s.id FROM table1 AS %[1]s WHERE EXISTS(SELECT 1 FROM table2 AS %[2]s WHERE (%[1]s.id = %[2]s.table1_id AND EXISTS(SELECT 1 FROM table3 AS %[3]s WHERE %[1]s.id = %[3]s.table1_id)))", table1.Alias, table2.Alias, table3.Alias)
+	if sql != st {
+		t.Errorf("bad returned sql. return:\n'%s'\n'%s'", sql, st)
+	}
+
+	q3.Where(WhereEqColumn{Table1: table4, Column1: "id", Table2: table3, Column2: "table4_id"})
+
+	if _, _, err = q.Get(); err == nil {
+		t.Error("expected error for unknown table")
+	}
+
+	if _, _, err = q2.Get(); err == nil {
+		t.Error("expected error for subquery without outer query")
+	}
+}
+
+func TestGetSub_join(t *testing.T) {
+	table1 := NewTable("table1")
+	table2 := NewTable("table2")
+	table3 := NewTable("table3")
+
+	sub := NewSelect()
+	sub.From(table3)
+	sub.Where(WhereEqColumn{Table1: table2, Column1: "id", Table2: table3, Column2: "table2_id"})
+
+	q := NewSelect()
+	q.From(table1)
+	q.Column(ColumnName{Table: table1, Name: "id"})
+	q.LeftJoin(table2, OnEq{Table1: table2, Table2: table1, Column1: "table1_id", Column2: "id"})
+	q.Where(WhereExists{Query: sub})
+
+	sql, _, err := q.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	st := fmt.Sprintf("SELECT %[1]s.id FROM table1 AS %[1]s LEFT JOIN table2 AS %[2]s ON %[2]s.table1_id = %[1]s.id WHERE EXISTS(SELECT 1 FROM table3 AS %[3]s WHERE %[2]s.id = %[3]s.table2_id)", table1.Alias, table2.Alias, table3.Alias)
+	if sql != st {
+		t.Errorf("bad returned sql. return:\n'%s'\n'%s'", sql, st)
+	}
+}
+
+func TestGetSub_update(t *testing.T) {
+	table1 := NewTable("table1")
+	table2 := NewTable("table2")
+
+	sub := NewSelect()
+	sub.From(table2)
+	sub.Column(ColumnCount{Alias: "c"})
+	sub.Where(WhereEqColumn{Table1: table1, Column1: "id", Table2: table2, Column2: "table1_id"})
+
+	q := NewUpdate(table1)
+	q.Set("total", ExprSub{Query: sub})
+
+	sql, _, err := q.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	st := fmt.Sprintf("UPDATE table1 AS %[1]s SET total = (SELECT COUNT(*) AS c FROM table2 AS %[2]s WHERE %[1]s.id = %[2]s.table1_id)", table1.Alias, table2.Alias)
+	if sql != st {
+		t.Errorf("bad returned sql. return:\n'%s'\n'%s'", sql, st)
+	}
+}
+
+func TestGetDerived(t *testing.T) {
+	table1 := NewTable("table1")
+	table2 := NewTable("table2")
+
+	sub := NewSelect()
+	sub.From(table2)
+	sub.Column(ColumnName{Table: table2, Name: "id"})
+	sub.Where(WhereEqColumn{Table1: table1, Column1: "id", Table2: table2, Column2: "table1_id"})
+
+	derived := NewTableSub(sub)
+
+	q := NewSelect()
+	q.From(table1, derived)
+	q.Column(ColumnName{Table: table1, Name: "id"})
+
+	if _, _, err := q.Get(); err == nil {
+		t.Error("expected error, derived table cannot see tables of the same level")
+	}
+
+	sub.Where(WhereEq{Table: table2, Column: "active", Value: true})
+
+	sql, _, err := q.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	st := fmt.Sprintf("SELECT %[1]s.id FROM table1 AS %[1]s, (SELECT %[2]s.id FROM table2 AS %[2]s WHERE %[2]s.active = @", table1.Alias, table2.Alias)
+	if len(sql) < len(st) || sql[:len(st)] != st {
+		t.Errorf("bad returned sql. return:\n'%s'\n'%s'", sql, st)
+	}
+}
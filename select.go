@@ -2,32 +2,41 @@ package builder
 
 import (
 	"fmt"
+	"maps"
+	"strings"
 )
 
 type SelectQuery struct {
-	from    []*Table
-	columns []Column
-	joins   []*join
-	where   Where
-	order   []Order
-	limit   string
-	offset  string
-	group   []Group
-	binds   map[string]any
-	isSub   bool
+	with
+	namer
+	scope
+	from       []*Table
+	columns    []Column
+	joins      []*join
+	joining    *join
+	keep       []*Table
+	where      Where
+	order      []Order
+	limit      int
+	offset     int
+	group      []Group
+	having     Where
+	windows    []window
+	locks      []Lock
+	distinct   bool
+	distinctOn []GroupColumn
+	after      []any
+	binds      map[string]any
 }
 
 func NewSelect() *SelectQuery {
 	return &SelectQuery{
+		namer: newNamer(),
 		binds: make(map[string]any),
 	}
 }
 
 func (q *SelectQuery) checkTable(table *Table) bool {
-	if q.isSub {
-		return true
-	}
-
 	for _, t := range q.from {
 		if t == table {
 			return true
@@ -36,19 +45,34 @@ func (q *SelectQuery) checkTable(table *Table) bool {
 
 	for _, j := range q.joins {
 		if j.Table == table {
-			j.Used = true
+			if q.joining != nil {
+				q.joining.deps = append(q.joining.deps, j)
+			} else {
+				j.Used = true
+			}
 
 			return true
 		}
 	}
 
-	return false
+	return q.checkOuter(table)
 }
 
 func (q *SelectQuery) addBind(key string, value any) {
 	q.binds[key] = value
 }
 
+func (q *SelectQuery) names() *namer {
+	return &q.namer
+}
+
+// Naming sets the alias and bind naming of the query, see Naming
+func (q *SelectQuery) Naming(n Naming) *SelectQuery {
+	q.namer.mode = n
+
+	return q
+}
+
 func (q *SelectQuery) From(t ...*Table) *SelectQuery {
 	q.from = append(q.from, t...)
 
@@ -61,16 +85,46 @@ func (q *SelectQuery) Column(c ...Column) *SelectQuery {
 	return q
 }
 
-func (q *SelectQuery) LeftJoin(table *Table, on On) *SelectQuery {
+// addJoin adds a join, only LEFT joins are left out when no clause references them,
+// other join types change the returned rows and are always rendered
+func (q *SelectQuery) addJoin(t joinType, table *Table, on On) *SelectQuery {
 	q.joins = append(q.joins, &join{
 		Table: table,
 		On:    on,
-		Left:  true,
+		Type:  t,
+		Used:  t != joinLeft,
 	})
 
 	return q
 }
 
+func (q *SelectQuery) InnerJoin(table *Table, on On) *SelectQuery {
+	return q.addJoin(joinInner, table, on)
+}
+
+func (q *SelectQuery) LeftJoin(table *Table, on On) *SelectQuery {
+	return q.addJoin(joinLeft, table, on)
+}
+
+func (q *SelectQuery) RightJoin(table *Table, on On) *SelectQuery {
+	return q.addJoin(joinRight, table, on)
+}
+
+func (q *SelectQuery) FullJoin(table *Table, on On) *SelectQuery {
+	return q.addJoin(joinFull, table, on)
+}
+
+func (q *SelectQuery) CrossJoin(table *Table) *SelectQuery {
+	return q.addJoin(joinCross, table, nil)
+}
+
+// KeepJoin renders joins of the tables even when no clause references them
+func (q *SelectQuery) KeepJoin(t ...*Table) *SelectQuery {
+	q.keep = append(q.keep, t...)
+
+	return q
+}
+
 func (q *SelectQuery) Where(w Where) *SelectQuery {
 	q.where = w
 
@@ -88,8 +142,7 @@ func (q *SelectQuery) Limit(limit int) *SelectQuery {
 		return q
 	}
 
-	q.limit = "limit_" + randStr()
-	q.addBind(q.limit, limit)
+	q.limit = limit
 
 	return q
 }
@@ -99,8 +152,7 @@ func (q *SelectQuery) Offset(offset int) *SelectQuery {
 		return q
 	}
 
-	q.offset = "offset_" + randStr()
-	q.addBind(q.offset, offset)
+	q.offset = offset
 
 	return q
 }
@@ -111,12 +163,51 @@ func (q *SelectQuery) Group(g ...Group) *SelectQuery {
 	return q
 }
 
-func (q *SelectQuery) IsSub() *SelectQuery {
-	q.isSub = true
+func (q *SelectQuery) Having(w Where) *SelectQuery {
+	q.having = w
 
 	return q
 }
 
+func (q *SelectQuery) Window(name string, def WindowDef) *SelectQuery {
+	q.windows = append(q.windows, window{Name: name, Def: def})
+
+	return q
+}
+
+func (q *SelectQuery) Lock(l ...Lock) *SelectQuery {
+	q.locks = append(q.locks, l...)
+
+	return q
+}
+
+func (q *SelectQuery) Distinct() *SelectQuery {
+	q.distinct = true
+
+	return q
+}
+
+// DistinctOn renders SELECT DISTINCT ON (...), leading ORDER BY terms must match the expressions
+func (q *SelectQuery) DistinctOn(g ...GroupColumn) *SelectQuery {
+	q.distinctOn = append(q.distinctOn, g...)
+
+	return q
+}
+
+// After continues keyset pagination from the last seen row values in the order of Order
+func (q *SelectQuery) After(values ...any) *SelectQuery {
+	q.after = values
+
+	return q
+}
+
+// IsSub does nothing, subqueries resolve tables of their outer queries.
+//
+// Deprecated: not needed anymore.
+func (q *SelectQuery) IsSub() *SelectQuery {
+	return q
+}
+
 func (q *SelectQuery) getSelect() (string, error) {
 	if len(q.columns) == 0 {
 		return "", fmt.Errorf("no columns defined for select")
@@ -124,6 +215,13 @@ func (q *SelectQuery) getSelect() (string, error) {
 
 	s := "SELECT "
 
+	distinct, err := q.getDistinct()
+	if err != nil {
+		return "", err
+	}
+
+	s += distinct
+
 	for i, col := range q.columns {
 		c, err := col.gen(q)
 		if err != nil {
@@ -140,6 +238,53 @@ func (q *SelectQuery) getSelect() (string, error) {
 	return s, nil
 }
 
+func (q *SelectQuery) getDistinct() (string, error) {
+	if len(q.distinctOn) == 0 {
+		if q.distinct {
+			return "DISTINCT ", nil
+		}
+
+		return "", nil
+	}
+
+	for i, o := range q.order {
+		if i == len(q.distinctOn) {
+			break
+		}
+
+		found := false
+
+		for _, d := range q.distinctOn {
+			if d.Table == o.Table && d.Column == o.Column {
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			return "", fmt.Errorf("order by %s does not match distinct on expressions", o.Column)
+		}
+	}
+
+	s := "DISTINCT ON ("
+
+	for i, d := range q.distinctOn {
+		sql, err := d.gen(q)
+		if err != nil {
+			return "", err
+		}
+
+		s += sql
+
+		if i != len(q.distinctOn)-1 {
+			s += ", "
+		}
+	}
+
+	return s + ") ", nil
+}
+
 func (q *SelectQuery) getFrom() (string, error) {
 	if len(q.from) == 0 {
 		return "", fmt.Errorf("no froms defined for select")
@@ -148,7 +293,7 @@ func (q *SelectQuery) getFrom() (string, error) {
 	s := " FROM "
 
 	for i, from := range q.from {
-		sql, binds, err := from.gen()
+		sql, binds, err := from.gen(q)
 		if err != nil {
 			return "", err
 		}
@@ -168,24 +313,44 @@ func (q *SelectQuery) getFrom() (string, error) {
 }
 
 func (q *SelectQuery) getWhere() (string, error) {
-	if q.where == nil {
-		return "", nil
+	var list []Where
+
+	if q.where != nil {
+		list = append(list, q.where)
 	}
 
-	where, binds, err := q.where.gen(q)
-	if err != nil {
-		return "", err
+	if q.after != nil {
+		list = append(list, WhereKeyset{Order: q.order, Values: q.after})
 	}
 
-	if where == "" {
-		return "", nil
+	s := ""
+
+	for _, w := range list {
+		where, binds, err := w.gen(q)
+		if err != nil {
+			return "", err
+		}
+
+		if where == "" {
+			continue
+		}
+
+		for k, v := range binds {
+			q.addBind(k, v)
+		}
+
+		if s != "" {
+			s += " AND "
+		}
+
+		s += where
 	}
 
-	for k, v := range binds {
-		q.addBind(k, v)
+	if s == "" {
+		return "", nil
 	}
 
-	return " WHERE " + where, nil
+	return " WHERE " + s, nil
 }
 
 func (q *SelectQuery) getOrder() (string, error) {
@@ -193,52 +358,89 @@ func (q *SelectQuery) getOrder() (string, error) {
 		return "", nil
 	}
 
-	s := " ORDER BY "
+	s, err := genOrder(q, q.order)
+	if err != nil {
+		return "", err
+	}
+
+	return " ORDER BY " + s, nil
+}
 
-	for i, o := range q.order {
-		if o.Table != nil {
-			if !q.checkTable(o.Table) {
-				return "", fmt.Errorf("table %s is not exist", o.Table)
-			}
+// genJoin renders j collecting joins referenced by its ON into j.deps
+func (q *SelectQuery) genJoin(j *join) (string, error) {
+	q.joining = j
+	defer func() {
+		q.joining = nil
+	}()
 
-			s += o.Table.Alias + "."
-		}
+	j.deps = nil
 
-		s += o.Column
+	return j.Gen(q)
+}
 
-		if o.Desc {
-			s += " DESC"
-		}
+// getJoin renders the joins referenced by other clauses, directly or through ON of other used joins.
+// Only rendered joins are checked, an unused LEFT join with a bad ON is left out without an error.
+func (q *SelectQuery) getJoin() (string, error) {
+	index := make(map[*join]int, len(q.joins))
 
-		if i != len(q.order)-1 {
-			s += ", "
-		}
+	for i, j := range q.joins {
+		index[j] = i
 	}
 
-	return s, nil
-}
+	for _, t := range q.keep {
+		found := false
 
-func (q *SelectQuery) getJoin() (string, error) {
-	if len(q.joins) == 0 {
-		return "", nil
+		for _, j := range q.joins {
+			if j.Table == t {
+				j.Used = true
+				found = true
+			}
+		}
+
+		if !found {
+			return "", fmt.Errorf("table %s is not joined", t)
+		}
 	}
 
-	s := ""
+	list := make([]string, len(q.joins))
+
+	// ON may reference only earlier joins, so walking back renders a join before its dependencies
+	for i := len(q.joins) - 1; i >= 0; i-- {
+		j := q.joins[i]
 
-	for _, j := range q.joins {
 		if !j.Used {
 			continue
 		}
 
-		sj, err := j.Gen(q)
+		sql, err := q.genJoin(j)
 		if err != nil {
 			return "", err
 		}
 
-		s += sj
+		for _, d := range j.deps {
+			if index[d] <= i {
+				d.Used = true
+
+				continue
+			}
+
+			if !d.Used {
+				if _, err = q.genJoin(d); err != nil {
+					return "", err
+				}
+			}
+
+			if d.dependsOn(j, make(map[*join]bool)) {
+				return "", fmt.Errorf("cyclic join reference between %s and %s", j.Table, d.Table)
+			}
+
+			return "", fmt.Errorf("join %s references %s joined after it", j.Table, d.Table)
+		}
+
+		list[i] = sql
 	}
 
-	return s, nil
+	return strings.Join(list, ""), nil
 }
 
 func (q *SelectQuery) getGroup() (string, error) {
@@ -264,7 +466,108 @@ func (q *SelectQuery) getGroup() (string, error) {
 	return s, nil
 }
 
+func (q *SelectQuery) getHaving() (string, error) {
+	if q.having == nil {
+		return "", nil
+	}
+
+	having, binds, err := q.having.gen(q)
+	if err != nil {
+		return "", err
+	}
+
+	if having == "" {
+		return "", nil
+	}
+
+	for k, v := range binds {
+		q.addBind(k, v)
+	}
+
+	return " HAVING " + having, nil
+}
+
+func (q *SelectQuery) getWindow() (string, error) {
+	if len(q.windows) == 0 {
+		return "", nil
+	}
+
+	s := " WINDOW "
+
+	for i, w := range q.windows {
+		if w.Name == "" {
+			return "", fmt.Errorf("window name is empty")
+		}
+
+		def, err := w.Def.gen(q)
+		if err != nil {
+			return "", err
+		}
+
+		s += quoteIdent(w.Name) + " AS " + def
+
+		if i != len(q.windows)-1 {
+			s += ", "
+		}
+	}
+
+	return s, nil
+}
+
+func (q *SelectQuery) getLock() (string, error) {
+	s := ""
+
+	for _, l := range q.locks {
+		sql, err := l.gen(q)
+		if err != nil {
+			return "", err
+		}
+
+		s += sql
+	}
+
+	return s, nil
+}
+
+// GetPositional returns SQL with $1..$n placeholders and ordered arguments for database/sql, lib/pq and pgx
+func (q *SelectQuery) GetPositional() (string, []any, error) {
+	return getPositional(q)
+}
+
 func (q *SelectQuery) Get() (string, map[string]any, error) {
+	return q.render(nil, nil)
+}
+
+// render builds a copy of the query, so Get() leaves the query untouched
+func (q *SelectQuery) render(root *namer, outer query) (string, map[string]any, error) {
+	return q.namer.get(root, func(root *namer) (string, map[string]any, error) {
+		c := q.clone()
+		c.namer.root = root
+		c.scope.parent = outer
+
+		return c.get()
+	})
+}
+
+func (q *SelectQuery) clone() *SelectQuery {
+	c := *q
+	c.binds = maps.Clone(q.binds)
+	c.joins = make([]*join, len(q.joins))
+
+	for i, j := range q.joins {
+		jc := *j
+		c.joins[i] = &jc
+	}
+
+	return &c
+}
+
+func (q *SelectQuery) get() (string, map[string]any, error) {
+	with, err := q.getWith(q)
+	if err != nil {
+		return "", nil, err
+	}
+
 	sel, err := q.getSelect()
 	if err != nil {
 		return "", nil, err
@@ -285,7 +588,17 @@ func (q *SelectQuery) Get() (string, map[string]any, error) {
 		return "", nil, err
 	}
 
-	j, err := q.getJoin()
+	having, err := q.getHaving()
+	if err != nil {
+		return "", nil, err
+	}
+
+	window, err := q.getWindow()
+	if err != nil {
+		return "", nil, err
+	}
+
+	lock, err := q.getLock()
 	if err != nil {
 		return "", nil, err
 	}
@@ -295,15 +608,24 @@ func (q *SelectQuery) Get() (string, map[string]any, error) {
 		return "", nil, err
 	}
 
+	j, err := q.getJoin()
+	if err != nil {
+		return "", nil, err
+	}
+
 	limit := ""
-	if q.limit != "" {
-		limit = " LIMIT @" + q.limit
+	if q.limit > 0 {
+		tag := bindTag(q, "limit")
+		q.addBind(tag, q.limit)
+		limit = " LIMIT @" + tag
 	}
 
 	offset := ""
-	if q.offset != "" {
-		offset = " OFFSET @" + q.offset
+	if q.offset > 0 {
+		tag := bindTag(q, "offset")
+		q.addBind(tag, q.offset)
+		offset = " OFFSET @" + tag
 	}
 
-	return sel + from + j + where + group + order + limit + offset, q.binds, nil
+	return with + sel + from + j + where + group + having + window + order + limit + offset + lock, q.binds, nil
 }
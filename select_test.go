@@ -2,6 +2,8 @@ package builder
 
 import (
 	"fmt"
+	"strings"
+	"sync"
 	"testing"
 )
 
@@ -42,6 +44,14 @@ func TestSelectQuery_checkTable(t *testing.T) {
 
 	q.IsSub()
 
+	if q.checkTable(table2) {
+		t.Errorf("q.checkTable() returned true")
+	}
+
+	outer := NewSelect()
+	outer.From(table2)
+	q.scope.parent = outer
+
 	if !q.checkTable(table2) {
 		t.Errorf("q.checkTable() returned false")
 	}
@@ -94,8 +104,8 @@ func TestSelectQuery_LeftJoin(t *testing.T) {
 	if q.joins[0].Table != table2 {
 		t.Errorf("q.joins[0].Table should have table %v", table2)
 	}
-	if !q.joins[0].Left {
-		t.Errorf("q.joins[0].Left should have true")
+	if q.joins[0].Type != joinLeft {
+		t.Errorf("q.joins[0].Type should have joinLeft")
 	}
 	if q.joins[0].Used {
 		t.Errorf("q.joins[0].Used should have false")
@@ -110,6 +120,66 @@ func TestSelectQuery_LeftJoin(t *testing.T) {
 	}
 }
 
+func TestSelectQuery_Joins(t *testing.T) {
+	table1 := NewTable("table1")
+	table2 := NewTable("table2")
+	table3 := NewTable("table3")
+	table4 := NewTable("table4")
+	table5 := NewTable("table5")
+
+	q := NewSelect()
+	q.From(table1)
+	q.Column(ColumnName{Table: table1, Name: "id"})
+	q.Column(ColumnName{Table: table2, Name: "col2"})
+	q.Column(ColumnName{Table: table3, Name: "col3"})
+	q.Column(ColumnName{Table: table4, Name: "col4"})
+	q.Column(ColumnName{Table: table5, Name: "col5"})
+	q.InnerJoin(table2, OnEq{Table1: table1, Table2: table2, Column1: "id", Column2: "table_id"})
+	q.RightJoin(table3, OnEq{Table1: table1, Table2: table3, Column1: "id", Column2: "table_id"})
+	q.FullJoin(table4, OnEq{Table1: table1, Table2: table4, Column1: "id", Column2: "table_id"})
+	q.CrossJoin(table5)
+
+	sql, _, err := q.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	st := fmt.Sprintf("SELECT %[1]s.id, %[2]s.col2, %[3]s.col3, %[4]s.col4, %[5]s.col5 FROM table1 AS %[1]s"+
+		" JOIN table2 AS %[2]s ON %[1]s.id = %[2]s.table_id"+
+		" RIGHT JOIN table3 AS %[3]s ON %[1]s.id = %[3]s.table_id"+
+		" FULL JOIN table4 AS %[4]s ON %[1]s.id = %[4]s.table_id"+
+		" CROSS JOIN table5 AS %[5]s", table1.Alias, table2.Alias, table3.Alias, table4.Alias, table5.Alias)
+	if sql != st {
+		t.Errorf("bad returned sql. return:\n'%s'\n'%s'", sql, st)
+	}
+}
+
+func TestSelectQuery_Joins_filter(t *testing.T) {
+	table1 := NewTable("table1")
+	table2 := NewTable("table2")
+	table3 := NewTable("table3")
+	table4 := NewTable("table4")
+
+	q := NewSelect()
+	q.From(table1)
+	q.Column(ColumnName{Table: table1, Name: "id"})
+	q.InnerJoin(table2, OnEq{Table1: table1, Table2: table2, Column1: "id", Column2: "table_id"})
+	q.LeftJoin(table3, OnEq{Table1: table1, Table2: table3, Column1: "id", Column2: "table_id"})
+	q.CrossJoin(table4)
+
+	sql, _, err := q.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	st := fmt.Sprintf("SELECT %[1]s.id FROM table1 AS %[1]s"+
+		" JOIN table2 AS %[2]s ON %[1]s.id = %[2]s.table_id"+
+		" CROSS JOIN table4 AS %[3]s", table1.Alias, table2.Alias, table4.Alias)
+	if sql != st {
+		t.Errorf("bad returned sql. return:\n'%s'\n'%s'", sql, st)
+	}
+}
+
 func TestSelectQuery_Where(t *testing.T) {
 	table := NewTable("table")
 
@@ -150,46 +220,46 @@ func TestSelectQuery_Order(t *testing.T) {
 }
 
 func TestSelectQuery_Limit(t *testing.T) {
-	q := NewSelect()
-	q.Limit(10)
+	table := &Table{Name: "items", Alias: "i"}
 
-	var (
-		tag   string
-		value int
-	)
+	q := NewSelect().Naming(NamingSequential)
+	q.From(table)
+	q.Column(ColumnName{Table: table, Name: "id"})
+	q.Limit(10)
 
-	for k, v := range q.binds {
-		tag, value = k, v.(int)
+	sql, binds, err := q.Get()
+	if err != nil {
+		t.Fatal(err)
 	}
 
-	if value != 10 {
-		t.Errorf("value should have 10")
+	if sql != "SELECT i.id FROM items AS i LIMIT @limit_1" {
+		t.Errorf("bad returned sql. return %s", sql)
 	}
 
-	if q.limit != tag {
-		t.Errorf("q.limit should have '%s'", tag)
+	if binds["limit_1"] != 10 {
+		t.Errorf("value should have 10")
 	}
 }
 
 func TestSelectQuery_Offset(t *testing.T) {
-	q := NewSelect()
-	q.Offset(10)
+	table := &Table{Name: "items", Alias: "i"}
 
-	var (
-		tag   string
-		value int
-	)
+	q := NewSelect().Naming(NamingSequential)
+	q.From(table)
+	q.Column(ColumnName{Table: table, Name: "id"})
+	q.Offset(10)
 
-	for k, v := range q.binds {
-		tag, value = k, v.(int)
+	sql, binds, err := q.Get()
+	if err != nil {
+		t.Fatal(err)
 	}
 
-	if value != 10 {
-		t.Errorf("value should have 10")
+	if sql != "SELECT i.id FROM items AS i OFFSET @offset_1" {
+		t.Errorf("bad returned sql. return %s", sql)
 	}
 
-	if q.offset != tag {
-		t.Errorf("q.offset should have '%s'", tag)
+	if binds["offset_1"] != 10 {
+		t.Errorf("value should have 10")
 	}
 }
 
@@ -216,10 +286,9 @@ func TestSelectQuery_Group(t *testing.T) {
 
 func TestSelectQuery_IsSub(t *testing.T) {
 	q := NewSelect()
-	q.IsSub()
 
-	if !q.isSub {
-		t.Errorf("q.isSub should have true")
+	if q.IsSub() != q {
+		t.Errorf("q.IsSub() should return q")
 	}
 }
 
@@ -246,6 +315,53 @@ func TestSelectQuery_getSelect(t *testing.T) {
 	}
 }
 
+func TestSelectQuery_getDistinct(t *testing.T) {
+	table := NewTable("table")
+
+	q := NewSelect()
+	q.From(table)
+	q.Column(ColumnName{Table: table, Name: "col1"}, ColumnName{Table: table, Name: "col2"})
+
+	s, err := q.getSelect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != fmt.Sprintf("SELECT %[1]s.col1, %[1]s.col2", table.Alias) {
+		t.Errorf("bad returned select. return %s", s)
+	}
+
+	q.Distinct()
+
+	s, err = q.getSelect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != fmt.Sprintf("SELECT DISTINCT %[1]s.col1, %[1]s.col2", table.Alias) {
+		t.Errorf("bad returned select. return %s", s)
+	}
+
+	q.DistinctOn(GroupColumn{Table: table, Column: "col1"}, GroupColumn{Table: table, Column: "col2"})
+	q.Order(Order{Table: table, Column: "col2"}, Order{Table: table, Column: "col1"}, Order{Table: table, Column: "col3", Desc: true})
+
+	s, err = q.getSelect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != fmt.Sprintf("SELECT DISTINCT ON (%[1]s.col1, %[1]s.col2) %[1]s.col1, %[1]s.col2", table.Alias) {
+		t.Errorf("bad returned select. return %s", s)
+	}
+
+	q2 := NewSelect()
+	q2.From(table)
+	q2.Column(ColumnName{Table: table, Name: "col1"})
+	q2.DistinctOn(GroupColumn{Table: table, Column: "col1"})
+	q2.Order(Order{Table: table, Column: "col3"}, Order{Table: table, Column: "col1"})
+
+	if _, _, err = q2.Get(); err == nil {
+		t.Errorf("q2.Get should have error")
+	}
+}
+
 func TestSelectQuery_getFrom(t *testing.T) {
 	table1 := NewTable("table1")
 	table2 := NewTable("table2")
@@ -417,6 +533,124 @@ func TestSelectQuery_getJoin(t *testing.T) {
 	}
 }
 
+func TestSelectQuery_getJoin_deps(t *testing.T) {
+	table1 := NewTable("table1")
+	table2 := NewTable("table2")
+	table3 := NewTable("table3")
+	table4 := NewTable("table4")
+
+	q := NewSelect()
+	q.From(table1)
+	q.Column(ColumnCount{Alias: "c"})
+	q.LeftJoin(table2, OnEq{Table1: table1, Table2: table2, Column1: "id", Column2: "table1_id"})
+	q.LeftJoin(table3, OnEq{Table1: table2, Table2: table3, Column1: "id", Column2: "table2_id"})
+	q.LeftJoin(table4, OnEq{Table1: table1, Table2: table4, Column1: "id", Column2: "table1_id"})
+	q.Group(GroupColumn{Table: table3, Column: "col"})
+
+	sql, _, err := q.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	st := fmt.Sprintf("SELECT COUNT(*) AS c FROM table1 AS %[1]s LEFT JOIN table2 AS %[2]s ON %[1]s.id = %[2]s.table1_id LEFT JOIN table3 AS %[3]s ON %[2]s.id = %[3]s.table2_id GROUP BY %[3]s.col", table1.Alias, table2.Alias, table3.Alias)
+	if sql != st {
+		t.Errorf("bad returned sql. return:\n'%s'\n'%s'", sql, st)
+	}
+
+	q.Having(HavingMore{Aggregate: Aggregate{Func: AggregateSum, Table: table4, Column: "amount"}, Value: 0})
+
+	sql, _, err = q.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(sql, " LEFT JOIN table4 AS "+table4.Alias) {
+		t.Errorf("join of table4 should be kept. return %s", sql)
+	}
+}
+
+func TestSelectQuery_KeepJoin(t *testing.T) {
+	table1 := NewTable("table1")
+	table2 := NewTable("table2")
+	table3 := NewTable("table3")
+
+	q := NewSelect()
+	q.From(table1)
+	q.Column(ColumnName{Table: table1, Name: "id"})
+	q.LeftJoin(table2, OnEq{Table1: table1, Table2: table2, Column1: "id", Column2: "table1_id"})
+	q.KeepJoin(table2)
+
+	sql, _, err := q.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	st := fmt.Sprintf("SELECT %[1]s.id FROM table1 AS %[1]s LEFT JOIN table2 AS %[2]s ON %[1]s.id = %[2]s.table1_id", table1.Alias, table2.Alias)
+	if sql != st {
+		t.Errorf("bad returned sql. return:\n'%s'\n'%s'", sql, st)
+	}
+
+	q.KeepJoin(table3)
+
+	if _, _, err = q.Get(); err == nil {
+		t.Error("expected error for table which is not joined")
+	}
+}
+
+func TestSelectQuery_getJoin_unused(t *testing.T) {
+	table1 := NewTable("table1")
+	table2 := NewTable("table2")
+	table3 := NewTable("table3")
+	table4 := NewTable("table4")
+
+	q := NewSelect()
+	q.From(table1)
+	q.Column(ColumnName{Table: table1, Name: "id"})
+	q.LeftJoin(table2, OnEq{Table1: table3, Table2: table2, Column1: "id", Column2: "table3_id"})
+	q.LeftJoin(table3, OnEq{Table1: table4, Table2: table3, Column1: "id", Column2: "table4_id"})
+
+	sql, _, err := q.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sql != "SELECT "+table1.Alias+".id FROM table1 AS "+table1.Alias {
+		t.Errorf("bad returned sql. return %s", sql)
+	}
+
+	q.KeepJoin(table3)
+
+	if _, _, err = q.Get(); err == nil {
+		t.Error("expected error for unknown table of a kept join")
+	}
+}
+
+func TestSelectQuery_getJoin_order(t *testing.T) {
+	table1 := NewTable("table1")
+	table2 := NewTable("table2")
+	table3 := NewTable("table3")
+
+	q := NewSelect()
+	q.From(table1)
+	q.Column(ColumnName{Table: table2, Name: "id"})
+	q.LeftJoin(table2, OnEq{Table1: table3, Table2: table2, Column1: "id", Column2: "table3_id"})
+	q.LeftJoin(table3, OnEq{Table1: table1, Table2: table3, Column1: "id", Column2: "table1_id"})
+
+	_, _, err := q.Get()
+	if err == nil || !strings.Contains(err.Error(), "joined after") {
+		t.Errorf("expected out of order error, got %v", err)
+	}
+
+	q = NewSelect()
+	q.From(table1)
+	q.Column(ColumnName{Table: table2, Name: "id"})
+	q.LeftJoin(table2, OnEq{Table1: table3, Table2: table2, Column1: "id", Column2: "table3_id"})
+	q.LeftJoin(table3, OnEq{Table1: table2, Table2: table3, Column1: "id", Column2: "table2_id"})
+
+	_, _, err = q.Get()
+	if err == nil || !strings.Contains(err.Error(), "cyclic") {
+		t.Errorf("expected cyclic error, got %v", err)
+	}
+}
+
 func TestSelectQuery_getGroup(t *testing.T) {
 	table := NewTable("table")
 
@@ -514,3 +748,84 @@ func ExampleNewSelect() {
 	// map[]
 	// <nil>
 }
+
+func TestSelectQuery_Get_repeat(t *testing.T) {
+	table1 := NewTable("table1")
+	table2 := NewTable("table2")
+
+	q := NewSelect()
+	q.From(table1)
+	q.Column(ColumnName{Table: table1, Name: "id"})
+	q.LeftJoin(table2, OnEq{Table1: table1, Table2: table2, Column1: "id", Column2: "table1_id"})
+	q.Where(WhereAnd{List: []Where{
+		WhereEq{Table: table1, Column: "col", Value: 1},
+		WhereIn{Table: table2, Column: "col", Values: []int{1, 2}},
+	}})
+	q.Limit(10)
+
+	sql1, binds1, err := q.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sql2, binds2, err := q.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if sql1 != sql2 {
+		t.Errorf("sql is changed. return:\n'%s'\n'%s'", sql1, sql2)
+	}
+	if len(binds1) != 3 || len(binds2) != 3 {
+		t.Errorf("binds should have 3 values, have %d and %d", len(binds1), len(binds2))
+	}
+	if len(q.binds) != 0 {
+		t.Errorf("q.binds should stay empty, have %d values", len(q.binds))
+	}
+	if q.joins[0].Used {
+		t.Errorf("join should not be marked")
+	}
+}
+
+func TestSelectQuery_Get_concurrent(t *testing.T) {
+	table := NewTable("table")
+
+	q := NewSelect()
+	q.From(table)
+	q.Column(ColumnName{Table: table, Name: "id"})
+	q.Where(WhereEq{Table: table, Column: "col", Value: 1})
+
+	st, _, err := q.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		wg   sync.WaitGroup
+		errs = make(chan error, 10)
+	)
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			sql, _, err := q.Get()
+			if err == nil && sql != st {
+				err = fmt.Errorf("sql is changed, sql is %s", sql)
+			}
+
+			errs <- err
+		}()
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Error(err)
+		}
+	}
+}
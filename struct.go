@@ -0,0 +1,207 @@
+package builder
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/xloss/go-builder/dbtag"
+)
+
+// structValue dereferences v, which must be a struct or a pointer to a struct
+func structValue(v any) (reflect.Value, error) {
+	rv := reflect.ValueOf(v)
+
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return reflect.Value{}, fmt.Errorf("struct cannot be nil")
+		}
+
+		rv = rv.Elem()
+	}
+
+	if rv.Kind() != reflect.Struct {
+		return reflect.Value{}, fmt.Errorf("%T is not a struct", v)
+	}
+
+	return rv, nil
+}
+
+// ValueStruct adds a Value for every db tagged field of v except readonly ones and empty omitempty ones
+func (q *InsertQuery) ValueStruct(v any) *InsertQuery {
+	rv, err := structValue(v)
+	if err != nil {
+		q.err = err
+
+		return q
+	}
+
+	for _, f := range dbtag.Fields(rv.Type()) {
+		fv, ok := f.Value(rv)
+		if !ok || f.ReadOnly || (f.OmitEmpty && fv.IsZero()) {
+			continue
+		}
+
+		q.Value(f.Column, fv.Interface())
+	}
+
+	return q
+}
+
+// RowsStruct adds a row for every element of slice, a slice of structs or pointers to structs.
+// An omitempty column is skipped when it is empty in every row, otherwise its empty values are DEFAULT.
+func (q *InsertQuery) RowsStruct(slice any) *InsertQuery {
+	rv := reflect.ValueOf(slice)
+	if rv.Kind() != reflect.Slice {
+		q.err = fmt.Errorf("%T is not a slice", slice)
+
+		return q
+	}
+
+	if rv.Len() == 0 {
+		q.err = fmt.Errorf("no rows")
+
+		return q
+	}
+
+	var (
+		values = make([]reflect.Value, rv.Len())
+		fields []dbtag.Field
+	)
+
+	for i := range values {
+		v, err := structValue(rv.Index(i).Interface())
+		if err != nil {
+			q.err = fmt.Errorf("row %d: %w", i, err)
+
+			return q
+		}
+
+		if i == 0 {
+			fields = dbtag.Fields(v.Type())
+		} else if v.Type() != values[0].Type() {
+			q.err = fmt.Errorf("row %d has type %s, expected %s", i, v.Type(), values[0].Type())
+
+			return q
+		}
+
+		values[i] = v
+	}
+
+	var (
+		columns []string
+		rows    = make([]map[string]any, len(values))
+	)
+
+	for i := range rows {
+		rows[i] = make(map[string]any)
+	}
+
+	for _, f := range fields {
+		if f.ReadOnly {
+			continue
+		}
+
+		used := !f.OmitEmpty
+
+		for _, v := range values {
+			if fv, ok := f.Value(v); ok && !fv.IsZero() {
+				used = true
+			}
+		}
+
+		if !used {
+			continue
+		}
+
+		columns = append(columns, f.Column)
+
+		for i, v := range values {
+			fv, ok := f.Value(v)
+
+			switch {
+			case !ok, f.OmitEmpty && fv.IsZero():
+				rows[i][f.Column] = ExprDefault{}
+			default:
+				rows[i][f.Column] = fv.Interface()
+			}
+		}
+	}
+
+	return q.Columns(columns...).Rows(rows...)
+}
+
+// SetStruct adds a Set for every db tagged field of v except readonly, pk and empty omitempty ones
+func (q *UpdateQuery) SetStruct(v any) *UpdateQuery {
+	rv, err := structValue(v)
+	if err != nil {
+		q.err = err
+
+		return q
+	}
+
+	for _, f := range dbtag.Fields(rv.Type()) {
+		fv, ok := f.Value(rv)
+		if !ok || f.ReadOnly || f.PK || (f.OmitEmpty && fv.IsZero()) {
+			continue
+		}
+
+		q.Set(f.Column, fv.Interface())
+	}
+
+	return q
+}
+
+// WherePK replaces the where of the query with a match on pk fields of v
+func (q *UpdateQuery) WherePK(v any) *UpdateQuery {
+	w, err := wherePK(q.table, v)
+	if err != nil {
+		q.err = err
+
+		return q
+	}
+
+	return q.Where(w)
+}
+
+// WherePK replaces the where of the query with a match on pk fields of v
+func (q *DeleteQuery) WherePK(v any) *DeleteQuery {
+	w, err := wherePK(q.table, v)
+	if err != nil {
+		q.err = err
+
+		return q
+	}
+
+	return q.Where(w)
+}
+
+func wherePK(table *Table, v any) (Where, error) {
+	rv, err := structValue(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var list []Where
+
+	for _, f := range dbtag.Fields(rv.Type()) {
+		if !f.PK {
+			continue
+		}
+
+		fv, ok := f.Value(rv)
+		if !ok {
+			return nil, fmt.Errorf("primary key %s is not set", f.Column)
+		}
+
+		list = append(list, WhereEq{Table: table, Column: f.Column, Value: fv.Interface()})
+	}
+
+	switch len(list) {
+	case 0:
+		return nil, fmt.Errorf("%s has no pk field", rv.Type())
+	case 1:
+		return list[0], nil
+	default:
+		return WhereAnd{List: list}, nil
+	}
+}
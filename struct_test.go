@@ -0,0 +1,187 @@
+package builder
+
+import (
+	"reflect"
+	"testing"
+)
+
+type testTimestamps struct {
+	CreatedAt string `db:"created_at,readonly"`
+	UpdatedAt string `db:"updated_at,omitempty"`
+}
+
+type testUser struct {
+	ID    int    `db:"id,pk,omitempty"`
+	Name  string `db:"name"`
+	Email string `db:"email,omitempty"`
+	Note  string `db:"-"`
+	Skip  string
+	testTimestamps
+}
+
+func TestStructValue(t *testing.T) {
+	if _, err := structValue(1); err == nil {
+		t.Error("expected error")
+	}
+	if _, err := structValue((*testUser)(nil)); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestInsertQuery_ValueStruct(t *testing.T) {
+	table := NewTable("users")
+
+	q := NewInsert(table)
+	q.ValueStruct(&testUser{Name: "name", Note: "note", Skip: "skip", testTimestamps: testTimestamps{CreatedAt: "now"}})
+
+	sql, binds, err := q.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(binds) != 1 {
+		t.Errorf("binds should have 1 values")
+	}
+
+	var tag string
+
+	for k := range binds {
+		tag = k
+	}
+
+	if sql != "INSERT INTO users AS "+table.Alias+" (name) VALUES (@"+tag+")" {
+		t.Errorf("bad returned sql. return %s", sql)
+	}
+
+	q = NewInsert(table)
+	q.ValueStruct("user")
+
+	if _, _, err = q.Get(); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestInsertQuery_RowsStruct(t *testing.T) {
+	table := NewTable("users")
+
+	q := NewInsert(table).Naming(NamingSequential)
+	q.RowsStruct([]*testUser{
+		{Name: "name1"},
+		{Name: "name2", Email: "email2"},
+	})
+
+	sql, binds, err := q.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if sql != "INSERT INTO users AS t1 (name, email) VALUES (@name_1, DEFAULT), (@name_2, @email_3)" {
+		t.Errorf("bad returned sql. return %s", sql)
+	}
+	if !reflect.DeepEqual(binds, map[string]any{"name_1": "name1", "name_2": "name2", "email_3": "email2"}) {
+		t.Errorf("bad returned binds. return %v", binds)
+	}
+
+	for _, v := range []any{testUser{}, []testUser{}, []any{testUser{}, 1}, []any{testUser{}, testTimestamps{}}} {
+		q = NewInsert(table)
+		q.RowsStruct(v)
+
+		if _, _, err = q.Get(); err == nil {
+			t.Errorf("expected error for %v", v)
+		}
+	}
+}
+
+func TestUpdateQuery_SetStruct(t *testing.T) {
+	table := NewTable("users")
+	user := testUser{ID: 5, Name: "name", testTimestamps: testTimestamps{UpdatedAt: "now"}}
+
+	q := NewUpdate(table).Naming(NamingSequential)
+	q.SetStruct(user).WherePK(&user)
+
+	sql, binds, err := q.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if sql != "UPDATE users AS t1 SET name = @name_1, updated_at = @updated_at_2 WHERE t1.id = @id_3" {
+		t.Errorf("bad returned sql. return %s", sql)
+	}
+	if !reflect.DeepEqual(binds, map[string]any{"name_1": "name", "updated_at_2": "now", "id_3": 5}) {
+		t.Errorf("bad returned binds. return %v", binds)
+	}
+
+	q = NewUpdate(table)
+	q.SetStruct(user).WherePK(testTimestamps{})
+
+	if _, _, err = q.Get(); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestDeleteQuery_WherePK(t *testing.T) {
+	type key struct {
+		TenantID int `db:"tenant_id,pk"`
+		ID       int `db:"id,pk"`
+	}
+
+	table := NewTable("users")
+
+	q := NewDelete(table).Naming(NamingSequential)
+	q.WherePK(key{TenantID: 1, ID: 2})
+
+	sql, binds, err := q.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if sql != "DELETE FROM users AS t1 WHERE (t1.tenant_id = @tenant_id_1 AND t1.id = @id_2)" {
+		t.Errorf("bad returned sql. return %s", sql)
+	}
+	if !reflect.DeepEqual(binds, map[string]any{"tenant_id_1": 1, "id_2": 2}) {
+		t.Errorf("bad returned binds. return %v", binds)
+	}
+
+	q = NewDelete(table)
+	q.WherePK(nil)
+
+	if _, _, err = q.Get(); err == nil {
+		t.Error("expected error")
+	}
+}
+
+type testNode struct {
+	*testNode
+	ID int `db:"id"`
+}
+
+func TestInsertQuery_ValueStruct_embedded(t *testing.T) {
+	type user struct {
+		testUser
+		Name string `db:"name"`
+	}
+
+	q := NewInsert(&Table{Name: "users", Alias: "u"}).Naming(NamingSequential)
+	q.ValueStruct(user{testUser: testUser{Name: "inner"}, Name: "outer"})
+
+	sql, binds, err := q.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if sql != "INSERT INTO users AS u (name) VALUES (@name_1)" {
+		t.Errorf("bad returned sql. return %s", sql)
+	}
+	if !reflect.DeepEqual(binds, map[string]any{"name_1": "outer"}) {
+		t.Errorf("bad returned binds. return %v", binds)
+	}
+
+	q = NewInsert(&Table{Name: "nodes", Alias: "n"}).Naming(NamingSequential)
+	q.ValueStruct(testNode{ID: 1})
+
+	if sql, _, err = q.Get(); err != nil {
+		t.Fatal(err)
+	}
+	if sql != "INSERT INTO nodes AS n (id) VALUES (@id_1)" {
+		t.Errorf("bad returned sql. return %s", sql)
+	}
+}
@@ -1,12 +1,62 @@
 package builder
 
+import (
+	"fmt"
+	"strconv"
+)
+
+type SampleMethod string
+
+const (
+	SampleBernoulli SampleMethod = "BERNOULLI"
+	SampleSystem    SampleMethod = "SYSTEM"
+)
+
+// TableSample is TABLESAMPLE method (percent) [REPEATABLE (seed)]
+type TableSample struct {
+	Method     SampleMethod // required
+	Percent    float64
+	Repeatable bool
+	Seed       int64
+}
+
+func (s TableSample) gen() (string, error) {
+	if !exprFuncName.MatchString(string(s.Method)) {
+		return "", fmt.Errorf("bad sample method %s", s.Method)
+	}
+
+	sql := " TABLESAMPLE " + string(s.Method) + " (" + strconv.FormatFloat(s.Percent, 'g', -1, 64) + ")"
+
+	if s.Repeatable {
+		sql += " REPEATABLE (" + strconv.FormatInt(s.Seed, 10) + ")"
+	}
+
+	return sql, nil
+}
+
 type Table struct {
-	Name  string
-	Alias string
-	Query query
+	Schema string
+	Name   string
+	Alias  string
+	Query  query
+	Only   bool // exclude inheritance children
+	Sample *TableSample
+	auto   bool
 }
 
-func (t Table) gen() (string, map[string]any, error) {
+func (t *Table) String() string {
+	if t == nil {
+		return "<nil>"
+	}
+
+	if t.Name == "" {
+		return t.Alias
+	}
+
+	return t.Name
+}
+
+func (t *Table) gen(q query) (string, map[string]any, error) {
 	var (
 		s     = ""
 		binds = make(map[string]any)
@@ -14,33 +64,84 @@ func (t Table) gen() (string, map[string]any, error) {
 	)
 
 	if t.Query != nil {
-		s, binds, err = t.Query.Get()
+		s, binds, err = getDerived(q, t.Query)
 		if err != nil {
 			return "", nil, err
 		}
 
 		s = "(" + s + ")"
 	} else {
-		s = t.Name
+		s = t.name()
 	}
 
-	s = s + " AS " + t.Alias
+	q.names().declare(t)
+
+	s = s + " AS " + t.alias(q)
+
+	if t.Sample != nil {
+		sample, err := t.Sample.gen()
+		if err != nil {
+			return "", nil, err
+		}
+
+		s += sample
+	}
 
 	return s, binds, nil
 }
 
+// name renders [ONLY ][schema.]name
+func (t *Table) name() string {
+	s := ""
+
+	if t.Only {
+		s = "ONLY "
+	}
+
+	if t.Schema != "" {
+		s += quoteIdent(t.Schema) + "."
+	}
+
+	return s + quoteIdent(t.Name)
+}
+
+// target renders the table of INSERT, UPDATE and DELETE
+func (t *Table) target(q query) (string, error) {
+	if t.Query != nil {
+		return "", fmt.Errorf("subquery cannot be a target table")
+	}
+
+	if t.Sample != nil {
+		return "", fmt.Errorf("table %s with tablesample cannot be a target table", t.Name)
+	}
+
+	q.names().declare(t)
+
+	return t.name() + " AS " + t.alias(q), nil
+}
+
 // Creating Table struct for use in Builder
 func NewTable(name string) *Table {
 	return &Table{
 		Name:  name,
 		Alias: name + "_" + randStr(),
+		auto:  true,
 	}
 }
 
+// Creating schema-qualified Table struct for use in Builder
+func NewTableSchema(schema, name string) *Table {
+	t := NewTable(name)
+	t.Schema = schema
+
+	return t
+}
+
 // Using Query as subquery in FROM
 func NewTableSub(q query) *Table {
 	return &Table{
 		Alias: randStr() + "_" + randStr(),
 		Query: q,
+		auto:  true,
 	}
 }
@@ -36,7 +36,7 @@ func TestNewTableSub(t *testing.T) {
 func TestTable_gen(t *testing.T) {
 	table1 := NewTable("table1")
 
-	sql, binds, err := table1.gen()
+	sql, binds, err := table1.gen(NewSelect())
 	if err != nil {
 		t.Error(err)
 	}
@@ -53,7 +53,7 @@ func TestTable_gen(t *testing.T) {
 
 	table2 := NewTableSub(q)
 
-	sql, binds, err = table2.gen()
+	sql, binds, err = table2.gen(NewSelect())
 	if err != nil {
 		t.Error(err)
 	}
@@ -65,6 +65,129 @@ func TestTable_gen(t *testing.T) {
 	}
 }
 
+func TestNewTableSchema(t *testing.T) {
+	table := NewTableSchema("sales", "orders")
+
+	if table.Schema != "sales" || table.Name != "orders" {
+		t.Errorf("table schema or name is wrong")
+	}
+
+	sql, _, err := table.gen(NewSelect())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sql != "sales.orders AS "+table.Alias {
+		t.Errorf("table sql is wrong, sql is '%s'", sql)
+	}
+
+	table = NewTableSchema("My Schema", "orders")
+
+	sql, _, err = table.gen(NewSelect())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sql != `"My Schema".orders AS `+table.Alias {
+		t.Errorf("table sql is wrong, sql is '%s'", sql)
+	}
+}
+
+func TestTable_options(t *testing.T) {
+	table := NewTableSchema("public", "events")
+	table.Only = true
+	table.Sample = &TableSample{Method: SampleBernoulli, Percent: 10, Repeatable: true, Seed: 42}
+
+	sql, _, err := table.gen(NewSelect())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sql != "ONLY public.events AS "+table.Alias+" TABLESAMPLE BERNOULLI (10) REPEATABLE (42)" {
+		t.Errorf("table sql is wrong, sql is '%s'", sql)
+	}
+
+	table.Sample = &TableSample{Method: "SYSTEM (1); --"}
+
+	if _, _, err = table.gen(NewSelect()); err == nil {
+		t.Error("expected error")
+	}
+
+	table.Sample = &TableSample{Method: SampleSystem, Percent: 0.5}
+
+	if _, err = table.target(NewDelete(table)); err == nil {
+		t.Error("expected error")
+	}
+
+	table.Sample = nil
+
+	sql, err = table.target(NewDelete(table))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sql != "ONLY public.events AS "+table.Alias {
+		t.Errorf("table sql is wrong, sql is '%s'", sql)
+	}
+}
+
+func TestTable_queries(t *testing.T) {
+	table1 := NewTableSchema("s1", "table1")
+	table1.Only = true
+	table2 := NewTableSchema("s2", "table2")
+	table2.Sample = &TableSample{Method: SampleSystem, Percent: 1}
+
+	q := NewSelect()
+	q.From(table1)
+	q.Column(ColumnName{Table: table1, Name: "id"}, ColumnName{Table: table2, Name: "id", Alias: "a1"})
+	q.InnerJoin(table2, OnEq{Table1: table1, Table2: table2, Column1: "id", Column2: "table1_id"})
+
+	sql, _, err := q.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	st := fmt.Sprintf("SELECT %[1]s.id, %[2]s.id AS a1 FROM ONLY s1.table1 AS %[1]s JOIN s2.table2 AS %[2]s TABLESAMPLE SYSTEM (1) ON %[1]s.id = %[2]s.table1_id", table1.Alias, table2.Alias)
+	if sql != st {
+		t.Errorf("bad returned sql. return:\n'%s'\n'%s'", sql, st)
+	}
+
+	u := NewUpdate(table1)
+	u.Set("col", 1)
+
+	sql, _, err = u.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(sql, "UPDATE ONLY s1.table1 AS "+table1.Alias+" SET") {
+		t.Errorf("bad returned sql. return %s", sql)
+	}
+
+	d := NewDelete(table1)
+	d.Full()
+
+	sql, _, err = d.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sql != "DELETE FROM ONLY s1.table1 AS "+table1.Alias {
+		t.Errorf("bad returned sql. return %s", sql)
+	}
+
+	i := NewInsert(table1)
+	i.Value("col", 1)
+
+	if _, _, err = i.Get(); err == nil {
+		t.Error("expected error")
+	}
+
+	table1.Only = false
+
+	sql, _, err = i.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(sql, "INSERT INTO s1.table1 AS "+table1.Alias+" (col)") {
+		t.Errorf("bad returned sql. return %s", sql)
+	}
+}
+
 func TestName(t *testing.T) {
 	table1 := NewTable("table1")
 	q1 := NewSelect()
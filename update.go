@@ -2,31 +2,59 @@ package builder
 
 import (
 	"fmt"
+	"maps"
 )
 
 type UpdateQuery struct {
+	with
+	namer
+	scope
 	table   *Table
+	from    []*Table
 	sets    []set
 	where   Where
 	binds   map[string]any
 	returns []Column
+	err     error
 }
 
 func NewUpdate(table *Table) *UpdateQuery {
 	return &UpdateQuery{
 		table: table,
+		namer: newNamer(),
 		binds: make(map[string]any),
 	}
 }
 
 func (q *UpdateQuery) checkTable(table *Table) bool {
-	return q.table == table
+	if q.table == table {
+		return true
+	}
+
+	for _, t := range q.from {
+		if t == table {
+			return true
+		}
+	}
+
+	return q.checkOuter(table)
 }
 
 func (q *UpdateQuery) addBind(key string, value any) {
 	q.binds[key] = value
 }
 
+func (q *UpdateQuery) names() *namer {
+	return &q.namer
+}
+
+// Naming sets the alias and bind naming of the query, see Naming
+func (q *UpdateQuery) Naming(n Naming) *UpdateQuery {
+	q.namer.mode = n
+
+	return q
+}
+
 func (q *UpdateQuery) Set(column string, value any) *UpdateQuery {
 	q.sets = append(q.sets, set{
 		Value:  value,
@@ -45,6 +73,13 @@ func (q *UpdateQuery) SetNow(column string) *UpdateQuery {
 	return q
 }
 
+// From adds tables for UPDATE ... FROM, they can be referenced in Where and Return
+func (q *UpdateQuery) From(t ...*Table) *UpdateQuery {
+	q.from = append(q.from, t...)
+
+	return q
+}
+
 func (q *UpdateQuery) Where(w Where) *UpdateQuery {
 	q.where = w
 
@@ -65,16 +100,17 @@ func (q *UpdateQuery) getSet() (string, error) {
 	s := " SET "
 
 	for i, st := range q.sets {
-		s += st.Column + " = "
+		s += quoteIdent(st.Column) + " = "
 
 		if st.Now {
 			s += "NOW()"
 		} else {
-			tag := st.Column + "_" + randStr()
+			v, err := genValue(q, st.Column, st.Value)
+			if err != nil {
+				return "", err
+			}
 
-			s += "@" + tag
-
-			q.addBind(tag, st.Value)
+			s += v
 		}
 
 		if i != len(q.sets)-1 {
@@ -85,6 +121,33 @@ func (q *UpdateQuery) getSet() (string, error) {
 	return s, nil
 }
 
+func (q *UpdateQuery) getFrom() (string, error) {
+	if len(q.from) == 0 {
+		return "", nil
+	}
+
+	s := " FROM "
+
+	for i, from := range q.from {
+		sql, binds, err := from.gen(q)
+		if err != nil {
+			return "", err
+		}
+
+		for k, v := range binds {
+			q.addBind(k, v)
+		}
+
+		s += sql
+
+		if i != len(q.from)-1 {
+			s += ", "
+		}
+	}
+
+	return s, nil
+}
+
 func (q *UpdateQuery) getWhere() (string, error) {
 	if q.where == nil {
 		return "", nil
@@ -129,16 +192,62 @@ func (q *UpdateQuery) getReturns() (string, error) {
 	return " RETURNING " + s, nil
 }
 
+// GetPositional returns SQL with $1..$n placeholders and ordered arguments for database/sql, lib/pq and pgx
+func (q *UpdateQuery) GetPositional() (string, []any, error) {
+	return getPositional(q)
+}
+
 func (q *UpdateQuery) Get() (string, map[string]any, error) {
+	return q.render(nil, nil)
+}
+
+// render builds a copy of the query, so Get() leaves the query untouched
+func (q *UpdateQuery) render(root *namer, outer query) (string, map[string]any, error) {
+	return q.namer.get(root, func(root *namer) (string, map[string]any, error) {
+		c := q.clone()
+		c.namer.root = root
+		c.scope.parent = outer
+
+		return c.get()
+	})
+}
+
+func (q *UpdateQuery) clone() *UpdateQuery {
+	c := *q
+	c.binds = maps.Clone(q.binds)
+
+	return &c
+}
+
+func (q *UpdateQuery) get() (string, map[string]any, error) {
+	if q.err != nil {
+		return "", nil, q.err
+	}
+
 	if q.table == nil {
 		return "", nil, fmt.Errorf("table not set")
 	}
 
+	table, err := q.table.target(q)
+	if err != nil {
+		return "", nil, err
+	}
+
+	with, err := q.getWith(q)
+	if err != nil {
+		return "", nil, err
+	}
+
 	sets, err := q.getSet()
 	if err != nil {
 		return "", nil, err
 	}
 
+	from, err := q.getFrom()
+	if err != nil {
+		return "", nil, err
+	}
+
 	where, err := q.getWhere()
 	if err != nil {
 		return "", nil, err
@@ -149,5 +258,5 @@ func (q *UpdateQuery) Get() (string, map[string]any, error) {
 		return "", nil, err
 	}
 
-	return "UPDATE " + q.table.Name + " AS " + q.table.Alias + sets + where + returns, q.binds, nil
+	return with + "UPDATE " + table + sets + from + where + returns, q.binds, nil
 }
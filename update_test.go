@@ -137,6 +137,60 @@ func TestUpdateQuery_getSet(t *testing.T) {
 	}
 }
 
+func TestUpdateQuery_From(t *testing.T) {
+	table1 := NewTable("table1")
+	table2 := NewTable("staging")
+	table3 := NewTable("table3")
+
+	sub := NewSelect()
+	sub.From(table3)
+	sub.Column(ColumnName{Table: table3, Name: "id"})
+	sub.Where(WhereEq{Table: table3, Column: "active", Value: true})
+
+	table4 := NewTableSub(sub)
+
+	q := NewUpdate(table1)
+
+	if q.checkTable(table2) {
+		t.Errorf("q.checkTable() returned true")
+	}
+
+	q.From(table2, table4)
+
+	if !q.checkTable(table2) || !q.checkTable(table4) {
+		t.Errorf("q.checkTable() returned false")
+	}
+
+	q.Set("col", 1)
+	q.Where(WhereAnd{List: []Where{
+		WhereEqColumn{Table1: table1, Column1: "id", Table2: table2, Column2: "id"},
+		WhereEqColumn{Table1: table1, Column1: "id", Table2: table4, Column2: "id"},
+	}})
+
+	sql, binds, err := q.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(binds) != 2 {
+		t.Fatalf("binds should have 2 values")
+	}
+
+	var tag1, tag2 string
+
+	for k, v := range binds {
+		if v == 1 {
+			tag1 = k
+		} else if v == true {
+			tag2 = k
+		}
+	}
+
+	st := fmt.Sprintf("UPDATE table1 AS %[1]s SET col = @%[5]s FROM staging AS %[2]s, (SELECT %[3]s.id FROM table3 AS %[3]s WHERE %[3]s.active = @%[6]s) AS %[4]s WHERE (%[1]s.id = %[2]s.id AND %[1]s.id = %[4]s.id)", table1.Alias, table2.Alias, table3.Alias, table4.Alias, tag1, tag2)
+	if sql != st {
+		t.Errorf("bad returned sql. return:\n'%s'\n'%s'", sql, st)
+	}
+}
+
 func TestUpdateQuery_getWhere(t *testing.T) {
 	table := NewTable("table")
 
@@ -245,7 +299,7 @@ func TestUpdateQuery_Get(t *testing.T) {
 
 	var val, where string
 
-	for k, v := range q.binds {
+	for k, v := range binds {
 		if v == "value1" {
 			val = k
 		} else if v == 5 {
@@ -253,8 +307,36 @@ func TestUpdateQuery_Get(t *testing.T) {
 		}
 	}
 
-	st := fmt.Sprintf("UPDATE %[1]s AS %[2]s SET col1 = @%[3]s, col2 = NOW() WHERE %[2]s.col3 = @%[4]s RETURNING %[2]s.col1, %[2]s.col2 AS a1", table.Name, table.Alias, val, where)
+	st := fmt.Sprintf("UPDATE \"%[1]s\" AS %[2]s SET col1 = @%[3]s, col2 = NOW() WHERE %[2]s.col3 = @%[4]s RETURNING %[2]s.col1, %[2]s.col2 AS a1", table.Name, table.Alias, val, where)
 	if sql != st {
 		t.Errorf("bad returned sql. return:\n'%s'\n'%s'", sql, st)
 	}
 }
+
+func TestUpdateQuery_Get_repeat(t *testing.T) {
+	table := NewTable("table")
+
+	q := NewUpdate(table)
+	q.Set("col1", "value1")
+	q.Where(WhereEq{Table: table, Column: "col2", Value: 5})
+
+	sql1, binds1, err := q.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sql2, binds2, err := q.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if sql1 != sql2 {
+		t.Errorf("sql is changed. return:\n'%s'\n'%s'", sql1, sql2)
+	}
+	if len(binds1) != 2 || len(binds2) != 2 {
+		t.Errorf("binds should have 2 values, have %d and %d", len(binds1), len(binds2))
+	}
+	if len(q.binds) != 0 {
+		t.Errorf("q.binds should have 0 values")
+	}
+}
@@ -24,9 +24,9 @@ func (w WhereEq) gen(q query) (string, map[string]any, error) {
 		return "", nil, fmt.Errorf("table %s does not exist", w.Table.Name)
 	}
 
-	tag := w.Column + "_" + randStr()
+	tag := bindTag(q, w.Column)
 
-	return w.Table.Alias + "." + w.Column + " = @" + tag, map[string]any{tag: w.Value}, nil
+	return w.Table.ref(q, w.Column) + " = @" + tag, map[string]any{tag: w.Value}, nil
 }
 
 type WhereNotEq struct {
@@ -44,9 +44,9 @@ func (w WhereNotEq) gen(q query) (string, map[string]any, error) {
 		return "", nil, fmt.Errorf("table %s does not exist", w.Table.Name)
 	}
 
-	tag := w.Column + "_" + randStr()
+	tag := bindTag(q, w.Column)
 
-	return w.Table.Alias + "." + w.Column + " <> @" + tag, map[string]any{tag: w.Value}, nil
+	return w.Table.ref(q, w.Column) + " <> @" + tag, map[string]any{tag: w.Value}, nil
 }
 
 type WhereEqColumn struct {
@@ -69,7 +69,7 @@ func (w WhereEqColumn) gen(q query) (string, map[string]any, error) {
 		return "", nil, fmt.Errorf("table %s does not exist", w.Table2.Name)
 	}
 
-	return w.Table1.Alias + "." + w.Column1 + " = " + w.Table2.Alias + "." + w.Column2, nil, nil
+	return w.Table1.ref(q, w.Column1) + " = " + w.Table2.ref(q, w.Column2), nil, nil
 }
 
 type WhereNotEqColumn struct {
@@ -92,7 +92,7 @@ func (w WhereNotEqColumn) gen(q query) (string, map[string]any, error) {
 		return "", nil, fmt.Errorf("table %s does not exist", w.Table2.Name)
 	}
 
-	return w.Table1.Alias + "." + w.Column1 + " <> " + w.Table2.Alias + "." + w.Column2, nil, nil
+	return w.Table1.ref(q, w.Column1) + " <> " + w.Table2.ref(q, w.Column2), nil, nil
 }
 
 type WhereIsNull struct {
@@ -109,7 +109,7 @@ func (w WhereIsNull) gen(q query) (string, map[string]any, error) {
 		return "", nil, fmt.Errorf("table %s does not exist", w.Table.Name)
 	}
 
-	return w.Table.Alias + "." + w.Column + " IS NULL", map[string]any{}, nil
+	return w.Table.ref(q, w.Column) + " IS NULL", map[string]any{}, nil
 }
 
 type WhereIsNotNull struct {
@@ -126,7 +126,7 @@ func (w WhereIsNotNull) gen(q query) (string, map[string]any, error) {
 		return "", nil, fmt.Errorf("table %s does not exist", w.Table.Name)
 	}
 
-	return w.Table.Alias + "." + w.Column + " IS NOT NULL", nil, nil
+	return w.Table.ref(q, w.Column) + " IS NOT NULL", nil, nil
 }
 
 type WhereIn struct {
@@ -144,9 +144,9 @@ func (w WhereIn) gen(q query) (string, map[string]any, error) {
 		return "", nil, fmt.Errorf("table %s does not exist", w.Table.Name)
 	}
 
-	tag := w.Column + "_" + randStr()
+	tag := bindTag(q, w.Column)
 
-	return w.Table.Alias + "." + w.Column + " = ANY(@" + tag + ")", map[string]any{tag: w.Values}, nil
+	return w.Table.ref(q, w.Column) + " = ANY(@" + tag + ")", map[string]any{tag: w.Values}, nil
 }
 
 type WhereMore struct {
@@ -164,9 +164,9 @@ func (w WhereMore) gen(q query) (string, map[string]any, error) {
 		return "", nil, fmt.Errorf("table %s does not exist", w.Table.Name)
 	}
 
-	tag := w.Column + "_" + randStr()
+	tag := bindTag(q, w.Column)
 
-	return w.Table.Alias + "." + w.Column + " > @" + tag, map[string]any{tag: w.Value}, nil
+	return w.Table.ref(q, w.Column) + " > @" + tag, map[string]any{tag: w.Value}, nil
 }
 
 type WhereLess struct {
@@ -184,11 +184,11 @@ func (w WhereLess) gen(q query) (string, map[string]any, error) {
 		return "", nil, fmt.Errorf("table %s does not exist", w.Table.Name)
 	}
 
-	tag := w.Column + "_" + randStr()
+	tag := bindTag(q, w.Column)
 
 	q.addBind(tag, w.Value)
 
-	return w.Table.Alias + "." + w.Column + " < @" + tag, map[string]any{tag: w.Value}, nil
+	return w.Table.ref(q, w.Column) + " < @" + tag, map[string]any{tag: w.Value}, nil
 }
 
 type WhereMoreEq struct {
@@ -206,11 +206,11 @@ func (w WhereMoreEq) gen(q query) (string, map[string]any, error) {
 		return "", nil, fmt.Errorf("table %s does not exist", w.Table.Name)
 	}
 
-	tag := w.Column + "_" + randStr()
+	tag := bindTag(q, w.Column)
 
 	q.addBind(tag, w.Value)
 
-	return w.Table.Alias + "." + w.Column + " >= @" + tag, map[string]any{tag: w.Value}, nil
+	return w.Table.ref(q, w.Column) + " >= @" + tag, map[string]any{tag: w.Value}, nil
 }
 
 type WhereLessEq struct {
@@ -228,11 +228,11 @@ func (w WhereLessEq) gen(q query) (string, map[string]any, error) {
 		return "", nil, fmt.Errorf("table %s does not exist", w.Table.Name)
 	}
 
-	tag := w.Column + "_" + randStr()
+	tag := bindTag(q, w.Column)
 
 	q.addBind(tag, w.Value)
 
-	return w.Table.Alias + "." + w.Column + " <= @" + tag, map[string]any{tag: w.Value}, nil
+	return w.Table.ref(q, w.Column) + " <= @" + tag, map[string]any{tag: w.Value}, nil
 }
 
 type WhereMoreColumn struct {
@@ -253,7 +253,7 @@ func (w WhereMoreColumn) gen(q query) (string, map[string]any, error) {
 		return "", nil, fmt.Errorf("table %s does not exist", w.Table2.Name)
 	}
 
-	return w.Table1.Alias + "." + w.Column1 + " > " + w.Table2.Alias + "." + w.Column2, map[string]any{}, nil
+	return w.Table1.ref(q, w.Column1) + " > " + w.Table2.ref(q, w.Column2), map[string]any{}, nil
 }
 
 type WhereILike struct {
@@ -271,9 +271,9 @@ func (w WhereILike) gen(q query) (string, map[string]any, error) {
 		return "", nil, fmt.Errorf("table %s does not exist", w.Table.Name)
 	}
 
-	tag := w.Column + "_" + randStr()
+	tag := bindTag(q, w.Column)
 
-	return w.Table.Alias + "." + w.Column + " ILIKE @" + tag, map[string]any{tag: w.Value}, nil
+	return w.Table.ref(q, w.Column) + " ILIKE @" + tag, map[string]any{tag: w.Value}, nil
 }
 
 type WhereFullText struct {
@@ -292,9 +292,9 @@ func (w WhereFullText) gen(q query) (string, map[string]any, error) {
 		return "", nil, fmt.Errorf("table %s does not exist", w.Table.Name)
 	}
 
-	tag := w.Column + "_" + randStr()
+	tag := bindTag(q, w.Column)
 
-	return "to_tsvector('" + w.Language + "', " + w.Table.Alias + "." + w.Column + ") @@ plainto_tsquery(@" + tag + ")", map[string]any{tag: w.Value}, nil
+	return "to_tsvector(" + QuoteLiteral(w.Language) + ", " + w.Table.ref(q, w.Column) + ") @@ plainto_tsquery(@" + tag + ")", map[string]any{tag: w.Value}, nil
 }
 
 type WhereAnd struct {
@@ -380,9 +380,9 @@ func (w WhereJsonbTextExist) gen(q query) (string, map[string]any, error) {
 		return "", nil, fmt.Errorf("table %s does not exist", w.Table.Name)
 	}
 
-	tag := w.Column + "_" + randStr()
+	tag := bindTag(q, w.Column)
 
-	return w.Table.Alias + "." + w.Column + " ? @" + tag, map[string]any{tag: w.Value}, nil
+	return w.Table.ref(q, w.Column) + " ? @" + tag, map[string]any{tag: w.Value}, nil
 }
 
 type WhereJsonbTextInExist struct {
@@ -400,13 +400,13 @@ func (w WhereJsonbTextInExist) gen(q query) (string, map[string]any, error) {
 		return "", nil, fmt.Errorf("table %s does not exist", w.Table.Name)
 	}
 
-	tag := w.Column + "_" + randStr()
+	tag := bindTag(q, w.Column)
 
-	return w.Table.Alias + "." + w.Column + " ?| @" + tag, map[string]any{tag: w.Values}, nil
+	return w.Table.ref(q, w.Column) + " ?| @" + tag, map[string]any{tag: w.Values}, nil
 }
 
 type WhereExists struct {
-	Query *SelectQuery
+	Query query
 }
 
 func (w WhereExists) gen(q query) (string, map[string]any, error) {
@@ -414,10 +414,23 @@ func (w WhereExists) gen(q query) (string, map[string]any, error) {
 		return "", nil, fmt.Errorf("query cannot be nil")
 	}
 
-	w.Query.IsSub()
-	w.Query.Column(ColumnValue{Value: 1})
+	if w.Query == nil {
+		return "", nil, fmt.Errorf("exists query cannot be nil")
+	}
+
+	sub := w.Query
+
+	if s, ok := w.Query.(*SelectQuery); ok {
+		if s == nil {
+			return "", nil, fmt.Errorf("exists query cannot be nil")
+		}
+
+		c := s.clone()
+		c.columns = append(c.columns[:len(c.columns):len(c.columns)], ColumnValue{Value: 1})
+		sub = c
+	}
 
-	sql, binds, err := w.Query.Get()
+	sql, binds, err := getSub(q, sub)
 	if err != nil {
 		return "", nil, err
 	}
@@ -690,3 +690,44 @@ func TestWhereExists_gen(t *testing.T) {
 		t.Errorf("sql is wrong, sql is %s", sql)
 	}
 }
+
+func TestWhereExists_gen_nil(t *testing.T) {
+	q := NewSelect()
+	q.From(NewTable("table"))
+
+	var sub *SelectQuery
+
+	if _, _, err := (WhereExists{Query: sub}).gen(q); err == nil {
+		t.Error("expected error for nil query")
+	}
+}
+
+func TestWhereExists_gen_repeat(t *testing.T) {
+	table1 := NewTable("table1")
+	table2 := NewTable("table2")
+	q1 := NewSelect()
+	q1.From(table1)
+	q2 := NewSelect()
+	q2.From(table2)
+	q2.IsSub()
+	q2.Where(WhereEqColumn{Table1: table1, Column1: "id", Table2: table2, Column2: "table1_id"})
+
+	where := WhereExists{Query: q2}
+
+	sql1, _, err := where.gen(q1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sql2, _, err := where.gen(q1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if sql1 != sql2 {
+		t.Errorf("sql is changed, sql is %s", sql2)
+	}
+	if len(q2.columns) != 0 {
+		t.Errorf("inner query columns should not be changed")
+	}
+}
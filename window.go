@@ -0,0 +1,290 @@
+package builder
+
+import (
+	"fmt"
+	"strconv"
+)
+
+type FrameMode string
+
+const (
+	FrameRows   FrameMode = "ROWS"
+	FrameRange  FrameMode = "RANGE"
+	FrameGroups FrameMode = "GROUPS"
+)
+
+type FrameBoundType int
+
+const (
+	FrameUnboundedPreceding FrameBoundType = iota + 1
+	FramePreceding
+	FrameCurrentRow
+	FrameFollowing
+	FrameUnboundedFollowing
+)
+
+type FrameBound struct {
+	Type   FrameBoundType // required
+	Offset int            // for FramePreceding and FrameFollowing
+}
+
+func (b FrameBound) gen() (string, error) {
+	switch b.Type {
+	case FrameUnboundedPreceding:
+		return "UNBOUNDED PRECEDING", nil
+	case FramePreceding:
+		return strconv.Itoa(b.Offset) + " PRECEDING", nil
+	case FrameCurrentRow:
+		return "CURRENT ROW", nil
+	case FrameFollowing:
+		return strconv.Itoa(b.Offset) + " FOLLOWING", nil
+	case FrameUnboundedFollowing:
+		return "UNBOUNDED FOLLOWING", nil
+	default:
+		return "", fmt.Errorf("frame bound is empty")
+	}
+}
+
+// Frame is rendered as "mode start" or "mode BETWEEN start AND end" when End is set
+type Frame struct {
+	Mode  FrameMode  // required
+	Start FrameBound // required
+	End   FrameBound
+}
+
+func (f Frame) gen() (string, error) {
+	if f.Mode == "" {
+		return "", fmt.Errorf("frame mode is empty")
+	}
+
+	start, err := f.Start.gen()
+	if err != nil {
+		return "", err
+	}
+
+	if f.End.Type == 0 {
+		return string(f.Mode) + " " + start, nil
+	}
+
+	end, err := f.End.gen()
+	if err != nil {
+		return "", err
+	}
+
+	return string(f.Mode) + " BETWEEN " + start + " AND " + end, nil
+}
+
+// WindowDef is a window definition, Name extends an existing named window
+type WindowDef struct {
+	Name      string
+	Partition []Group
+	Order     []Order
+	Frame     *Frame
+}
+
+func (w WindowDef) gen(q query) (string, error) {
+	s := ""
+
+	if w.Name != "" {
+		s = quoteIdent(w.Name)
+	}
+
+	if len(w.Partition) != 0 {
+		if s != "" {
+			s += " "
+		}
+
+		s += "PARTITION BY "
+
+		for i, g := range w.Partition {
+			sql, err := g.gen(q)
+			if err != nil {
+				return "", err
+			}
+
+			s += sql
+
+			if i != len(w.Partition)-1 {
+				s += ", "
+			}
+		}
+	}
+
+	if len(w.Order) != 0 {
+		order, err := genOrder(q, w.Order)
+		if err != nil {
+			return "", err
+		}
+
+		if s != "" {
+			s += " "
+		}
+
+		s += "ORDER BY " + order
+	}
+
+	if w.Frame != nil {
+		frame, err := w.Frame.gen()
+		if err != nil {
+			return "", err
+		}
+
+		if s != "" {
+			s += " "
+		}
+
+		s += frame
+	}
+
+	return "(" + s + ")", nil
+}
+
+type window struct {
+	Name string
+	Def  WindowDef
+}
+
+// WindowFunc is a function for use with ColumnOver. Aggregate is also a WindowFunc.
+type WindowFunc interface {
+	gen(q query) (string, error)
+}
+
+type WindowRowNumber struct{}
+
+func (w WindowRowNumber) gen(_ query) (string, error) {
+	return "ROW_NUMBER()", nil
+}
+
+type WindowRank struct{}
+
+func (w WindowRank) gen(_ query) (string, error) {
+	return "RANK()", nil
+}
+
+type WindowDenseRank struct{}
+
+func (w WindowDenseRank) gen(_ query) (string, error) {
+	return "DENSE_RANK()", nil
+}
+
+type WindowNtile struct {
+	Buckets int // required
+}
+
+func (w WindowNtile) gen(_ query) (string, error) {
+	if w.Buckets <= 0 {
+		return "", fmt.Errorf("buckets must be positive")
+	}
+
+	return "NTILE(" + strconv.Itoa(w.Buckets) + ")", nil
+}
+
+type WindowFirstValue struct {
+	Table  *Table // required
+	Column string // required
+}
+
+func (w WindowFirstValue) gen(q query) (string, error) {
+	if !q.checkTable(w.Table) {
+		return "", fmt.Errorf("table %s does not exist", w.Table)
+	}
+
+	if w.Column == "" {
+		return "", fmt.Errorf("column is empty")
+	}
+
+	return "FIRST_VALUE(" + w.Table.ref(q, w.Column) + ")", nil
+}
+
+func genLagLead(q query, fn string, table *Table, column string, offset int, def any) (string, error) {
+	if !q.checkTable(table) {
+		return "", fmt.Errorf("table %s does not exist", table)
+	}
+
+	if column == "" {
+		return "", fmt.Errorf("column is empty")
+	}
+
+	s := fn + "(" + table.ref(q, column)
+
+	if offset > 0 || def != nil {
+		if offset <= 0 {
+			offset = 1
+		}
+
+		s += ", " + strconv.Itoa(offset)
+	}
+
+	if def != nil {
+		tag := bindTag(q, column)
+
+		q.addBind(tag, def)
+
+		s += ", @" + tag
+	}
+
+	return s + ")", nil
+}
+
+type WindowLag struct {
+	Table   *Table // required
+	Column  string // required
+	Offset  int
+	Default any
+}
+
+func (w WindowLag) gen(q query) (string, error) {
+	return genLagLead(q, "LAG", w.Table, w.Column, w.Offset, w.Default)
+}
+
+type WindowLead struct {
+	Table   *Table // required
+	Column  string // required
+	Offset  int
+	Default any
+}
+
+func (w WindowLead) gen(q query) (string, error) {
+	return genLagLead(q, "LEAD", w.Table, w.Column, w.Offset, w.Default)
+}
+
+// ColumnOver is a window function column. Window references a named window of SelectQuery.Window,
+// Over defines the window inline.
+type ColumnOver struct {
+	Func   WindowFunc // required
+	Window string
+	Over   *WindowDef
+	Alias  string // required
+}
+
+func (c ColumnOver) gen(q query) (string, error) {
+	if c.Func == nil {
+		return "", fmt.Errorf("func is empty")
+	}
+
+	if c.Alias == "" {
+		return "", fmt.Errorf("alias is empty")
+	}
+
+	if c.Window != "" && c.Over != nil {
+		return "", fmt.Errorf("use Over.Name to extend window %s", c.Window)
+	}
+
+	fn, err := c.Func.gen(q)
+	if err != nil {
+		return "", err
+	}
+
+	over := "()"
+
+	if c.Window != "" {
+		over = quoteIdent(c.Window)
+	} else if c.Over != nil {
+		over, err = c.Over.gen(q)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return fn + " OVER " + over + " AS " + quoteIdent(c.Alias), nil
+}
@@ -0,0 +1,209 @@
+package builder
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestFrame_gen(t *testing.T) {
+	f := Frame{Mode: FrameRows, Start: FrameBound{Type: FrameUnboundedPreceding}, End: FrameBound{Type: FrameCurrentRow}}
+
+	s, err := f.gen()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != "ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW" {
+		t.Fatal(s)
+	}
+
+	f = Frame{Mode: FrameRange, Start: FrameBound{Type: FramePreceding, Offset: 2}, End: FrameBound{Type: FrameFollowing, Offset: 3}}
+
+	s, err = f.gen()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != "RANGE BETWEEN 2 PRECEDING AND 3 FOLLOWING" {
+		t.Fatal(s)
+	}
+
+	f = Frame{Mode: FrameGroups, Start: FrameBound{Type: FrameCurrentRow}}
+
+	s, err = f.gen()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != "GROUPS CURRENT ROW" {
+		t.Fatal(s)
+	}
+
+	if _, err = (Frame{Start: FrameBound{Type: FrameCurrentRow}}).gen(); err == nil {
+		t.Error("expected error")
+	}
+	if _, err = (Frame{Mode: FrameRows}).gen(); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestWindowDef_gen(t *testing.T) {
+	table := NewTable("table")
+	q := NewSelect()
+	q.From(table)
+
+	s, err := WindowDef{}.gen(q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != "()" {
+		t.Fatal(s)
+	}
+
+	w := WindowDef{
+		Name:      "w",
+		Partition: []Group{GroupColumn{Table: table, Column: "user_id"}},
+		Order:     []Order{{Table: table, Column: "created_at"}},
+		Frame:     &Frame{Mode: FrameRows, Start: FrameBound{Type: FrameUnboundedPreceding}, End: FrameBound{Type: FrameCurrentRow}},
+	}
+
+	s, err = w.gen(q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != fmt.Sprintf("(w PARTITION BY %[1]s.user_id ORDER BY %[1]s.created_at ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW)", table.Alias) {
+		t.Fatal(s)
+	}
+}
+
+func TestWindowFunc_gen(t *testing.T) {
+	table := NewTable("table")
+	q := NewSelect()
+	q.From(table)
+
+	tests := []struct {
+		fn WindowFunc
+		s  string
+	}{
+		{WindowRowNumber{}, "ROW_NUMBER()"},
+		{WindowRank{}, "RANK()"},
+		{WindowDenseRank{}, "DENSE_RANK()"},
+		{WindowNtile{Buckets: 4}, "NTILE(4)"},
+		{WindowFirstValue{Table: table, Column: "col"}, "FIRST_VALUE(" + table.Alias + ".col)"},
+		{WindowLag{Table: table, Column: "col"}, "LAG(" + table.Alias + ".col)"},
+		{WindowLead{Table: table, Column: "col", Offset: 2}, "LEAD(" + table.Alias + ".col, 2)"},
+		{Aggregate{Func: AggregateSum, Table: table, Column: "col"}, "SUM(" + table.Alias + ".col)"},
+	}
+
+	for _, tt := range tests {
+		s, err := tt.fn.gen(q)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s != tt.s {
+			t.Errorf("bad returned func. return %s", s)
+		}
+	}
+
+	s, err := WindowLag{Table: table, Column: "col", Default: 0}.gen(q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(q.binds) != 1 {
+		t.Fatalf("q.binds should have 1 values")
+	}
+
+	var tag string
+
+	for k := range q.binds {
+		tag = k
+	}
+
+	if s != "LAG("+table.Alias+".col, 1, @"+tag+")" {
+		t.Errorf("bad returned func. return %s", s)
+	}
+
+	if _, err = (WindowNtile{}).gen(q); err == nil {
+		t.Error("expected error")
+	}
+	if _, err = (WindowFirstValue{Table: NewTable("table2"), Column: "col"}).gen(q); err == nil {
+		t.Error("expected error")
+	}
+	if _, err = (WindowLead{Table: table}).gen(q); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestColumnOver_gen(t *testing.T) {
+	table := NewTable("table")
+	q := NewSelect()
+	q.From(table)
+
+	s, err := ColumnOver{Func: WindowRowNumber{}, Alias: "n"}.gen(q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != "ROW_NUMBER() OVER () AS n" {
+		t.Fatal(s)
+	}
+
+	s, err = ColumnOver{Func: WindowRank{}, Window: "w", Alias: "r"}.gen(q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != "RANK() OVER w AS r" {
+		t.Fatal(s)
+	}
+
+	s, err = ColumnOver{Func: WindowRank{}, Over: &WindowDef{Order: []Order{{Table: table, Column: "score", Desc: true}}}, Alias: "r"}.gen(q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != "RANK() OVER (ORDER BY "+table.Alias+".score DESC) AS r" {
+		t.Fatal(s)
+	}
+
+	if _, err = (ColumnOver{Func: WindowRank{}}).gen(q); err == nil {
+		t.Error("expected error")
+	}
+	if _, err = (ColumnOver{Alias: "r"}).gen(q); err == nil {
+		t.Error("expected error")
+	}
+	if _, err = (ColumnOver{Func: WindowRank{}, Window: "w", Over: &WindowDef{}, Alias: "r"}).gen(q); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestSelectQuery_Window(t *testing.T) {
+	table := NewTable("scores")
+
+	q := NewSelect()
+	q.From(table)
+	q.Column(
+		ColumnName{Table: table, Name: "user_id"},
+		ColumnOver{Func: WindowRank{}, Window: "w", Alias: "place"},
+		ColumnOver{Func: Aggregate{Func: AggregateSum, Table: table, Column: "score"}, Over: &WindowDef{
+			Name:  "w",
+			Frame: &Frame{Mode: FrameRows, Start: FrameBound{Type: FrameUnboundedPreceding}, End: FrameBound{Type: FrameCurrentRow}},
+		}, Alias: "total"},
+	)
+	q.Window("w", WindowDef{
+		Partition: []Group{GroupColumn{Table: table, Column: "league_id"}},
+		Order:     []Order{{Table: table, Column: "score", Desc: true}},
+	})
+	q.Order(Order{Column: "place"})
+
+	sql, _, err := q.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	st := fmt.Sprintf("SELECT %[1]s.user_id, RANK() OVER w AS place, SUM(%[1]s.score) OVER (w ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW) AS total"+
+		" FROM scores AS %[1]s WINDOW w AS (PARTITION BY %[1]s.league_id ORDER BY %[1]s.score DESC) ORDER BY place", table.Alias)
+	if sql != st {
+		t.Errorf("bad returned sql. return:\n'%s'\n'%s'", sql, st)
+	}
+
+	q.Window("", WindowDef{})
+
+	if _, _, err = q.Get(); err == nil {
+		t.Error("expected error")
+	}
+}
@@ -0,0 +1,140 @@
+package builder
+
+import (
+	"fmt"
+	"strings"
+)
+
+type cteMaterialized int
+
+const (
+	cteDefault cteMaterialized = iota
+	cteMaterialize
+	cteNotMaterialize
+)
+
+type cte struct {
+	Table        *Table
+	Query        query
+	Recursive    query
+	Materialized cteMaterialized
+}
+
+func (c cte) gen(q query) (string, map[string]any, error) {
+	if c.Query == nil {
+		return "", nil, fmt.Errorf("query is empty for cte %s", c.Table.Name)
+	}
+
+	binds := make(map[string]any)
+
+	sql, b, err := getDerived(q, c.Query)
+	if err != nil {
+		return "", nil, err
+	}
+
+	for k, v := range b {
+		binds[k] = v
+	}
+
+	if c.Recursive != nil {
+		rec, b, err := getDerived(q, c.Recursive)
+		if err != nil {
+			return "", nil, err
+		}
+
+		for k, v := range b {
+			binds[k] = v
+		}
+
+		sql = "(" + sql + ") UNION ALL (" + rec + ")"
+	}
+
+	s := quoteIdent(c.Table.Name) + " AS "
+
+	switch c.Materialized {
+	case cteMaterialize:
+		s += "MATERIALIZED "
+	case cteNotMaterialize:
+		s += "NOT MATERIALIZED "
+	}
+
+	return s + "(" + sql + ")", binds, nil
+}
+
+type with struct {
+	ctes []*cte
+}
+
+func (w *with) add(name string, c *cte) *Table {
+	c.Table = NewTable(name)
+
+	w.ctes = append(w.ctes, c)
+
+	return c.Table
+}
+
+// With registers a common table expression and returns the table to reference it
+func (w *with) With(name string, q query) *Table {
+	return w.add(name, &cte{Query: q})
+}
+
+// WithMaterialized registers a common table expression rendered AS MATERIALIZED
+func (w *with) WithMaterialized(name string, q query) *Table {
+	return w.add(name, &cte{Query: q, Materialized: cteMaterialize})
+}
+
+// WithNotMaterialized registers a common table expression rendered AS NOT MATERIALIZED
+func (w *with) WithNotMaterialized(name string, q query) *Table {
+	return w.add(name, &cte{Query: q, Materialized: cteNotMaterialize})
+}
+
+// WithRecursive registers a recursive common table expression: (base) UNION ALL (recursive).
+// The recursive callback receives the cte table to reference it from the recursive part.
+func (w *with) WithRecursive(name string, base query, recursive func(t *Table) *SelectQuery) *Table {
+	c := &cte{Query: base}
+	t := w.add(name, c)
+
+	if recursive != nil {
+		if r := recursive(t); r != nil {
+			c.Recursive = r
+		}
+	}
+
+	return t
+}
+
+func (w *with) getWith(q query) (string, error) {
+	if len(w.ctes) == 0 {
+		return "", nil
+	}
+
+	var (
+		list      = make([]string, len(w.ctes))
+		recursive = false
+	)
+
+	for i, c := range w.ctes {
+		sql, binds, err := c.gen(q)
+		if err != nil {
+			return "", err
+		}
+
+		for k, v := range binds {
+			q.addBind(k, v)
+		}
+
+		if c.Recursive != nil {
+			recursive = true
+		}
+
+		list[i] = sql
+	}
+
+	s := "WITH "
+
+	if recursive {
+		s += "RECURSIVE "
+	}
+
+	return s + strings.Join(list, ", ") + " ", nil
+}
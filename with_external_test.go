@@ -0,0 +1,49 @@
+package builder_test
+
+import (
+	"testing"
+
+	builder "github.com/xloss/go-builder"
+)
+
+func TestWithRecursive_external(t *testing.T) {
+	categories := builder.NewTable("categories")
+
+	base := builder.NewSelect()
+	base.From(categories)
+	base.Column(builder.ColumnName{Table: categories, Name: "id"})
+	base.Where(builder.WhereIsNull{Table: categories, Column: "parent_id"})
+	base.Order(builder.Order{Table: categories, Column: "id"})
+	base.Limit(10)
+
+	q := builder.NewSelect().Naming(builder.NamingSequential)
+
+	tree := q.WithRecursive("tree", base, func(tree *builder.Table) *builder.SelectQuery {
+		child := builder.NewTable("categories")
+
+		return builder.NewSelect().
+			From(child).
+			Column(builder.ColumnName{Table: child, Name: "id"}).
+			InnerJoin(tree, builder.OnEq{Table1: tree, Table2: child, Column1: "id", Column2: "parent_id"})
+	})
+
+	q.From(tree)
+	q.Column(builder.ColumnName{Table: tree, Name: "id"})
+
+	sql, binds, err := q.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	st := "WITH RECURSIVE tree AS (" +
+		"(SELECT t1.id FROM categories AS t1 WHERE t1.parent_id IS NULL ORDER BY t1.id LIMIT @limit_1)" +
+		" UNION ALL " +
+		"(SELECT t2.id FROM categories AS t2 JOIN tree AS t3 ON t3.id = t2.parent_id)" +
+		") SELECT t3.id FROM tree AS t3"
+	if sql != st {
+		t.Errorf("bad returned sql. return:\n'%s'\n'%s'", sql, st)
+	}
+	if len(binds) != 1 || binds["limit_1"] != 10 {
+		t.Errorf("bad returned binds. return %v", binds)
+	}
+}
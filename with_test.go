@@ -0,0 +1,166 @@
+package builder
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestWith_With(t *testing.T) {
+	table := NewTable("table")
+
+	sub := NewSelect()
+	sub.From(table)
+	sub.Column(ColumnName{Table: table, Name: "id"})
+
+	q := NewSelect()
+	c1 := q.With("c1", sub)
+	c2 := q.WithMaterialized("c2", sub)
+	c3 := q.WithNotMaterialized("c3", sub)
+
+	if len(q.ctes) != 3 {
+		t.Fatalf("q.ctes should have 3 values")
+	}
+	if c1.Name != "c1" || c2.Name != "c2" || c3.Name != "c3" {
+		t.Errorf("bad cte table names")
+	}
+	if q.ctes[0].Materialized != cteDefault {
+		t.Errorf("q.ctes[0].Materialized should have cteDefault")
+	}
+	if q.ctes[1].Materialized != cteMaterialize {
+		t.Errorf("q.ctes[1].Materialized should have cteMaterialize")
+	}
+	if q.ctes[2].Materialized != cteNotMaterialize {
+		t.Errorf("q.ctes[2].Materialized should have cteNotMaterialize")
+	}
+}
+
+func TestWith_getWith(t *testing.T) {
+	table := NewTable("table")
+
+	sub := NewSelect()
+	sub.From(table)
+	sub.Column(ColumnName{Table: table, Name: "id"})
+	sub.Where(WhereEq{Table: table, Column: "col", Value: 5})
+
+	q := NewSelect()
+
+	s, err := q.getWith(q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != "" {
+		t.Errorf("with should have empty string")
+	}
+
+	q.WithMaterialized("c1", sub)
+
+	s, err = q.getWith(q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(q.binds) != 1 {
+		t.Fatalf("q.binds should have 1 values")
+	}
+
+	var tag string
+
+	for k := range q.binds {
+		tag = k
+	}
+
+	st := fmt.Sprintf("WITH c1 AS MATERIALIZED (SELECT %[1]s.id FROM \"table\" AS %[1]s WHERE %[1]s.col = @%[2]s) ", table.Alias, tag)
+	if s != st {
+		t.Errorf("bad returned with. return:\n'%s'\n'%s'", s, st)
+	}
+
+	q.With("c2", NewSelect())
+
+	if _, err = q.getWith(q); err == nil {
+		t.Errorf("q.getWith should have error")
+	}
+}
+
+func TestWith_WithRecursive(t *testing.T) {
+	table := NewTable("categories")
+
+	base := NewSelect()
+	base.From(table)
+	base.Column(ColumnName{Table: table, Name: "id"}, ColumnName{Table: table, Name: "parent_id"})
+	base.Where(WhereIsNull{Table: table, Column: "parent_id"})
+
+	q := NewSelect()
+
+	var child *Table
+
+	tree := q.WithRecursive("tree", base, func(tree *Table) *SelectQuery {
+		child = NewTable("categories")
+
+		r := NewSelect()
+		r.From(child, tree)
+		r.Column(ColumnName{Table: child, Name: "id"}, ColumnName{Table: child, Name: "parent_id"})
+		r.Where(WhereEqColumn{Table1: child, Table2: tree, Column1: "parent_id", Column2: "id"})
+
+		return r
+	})
+
+	q.From(tree)
+	q.Column(ColumnName{Table: tree, Name: "id"})
+
+	sql, _, err := q.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	st := fmt.Sprintf("WITH RECURSIVE tree AS ("+
+		"(SELECT %[1]s.id, %[1]s.parent_id FROM categories AS %[1]s WHERE %[1]s.parent_id IS NULL)"+
+		" UNION ALL "+
+		"(SELECT %[2]s.id, %[2]s.parent_id FROM categories AS %[2]s, tree AS %[3]s WHERE %[2]s.parent_id = %[3]s.id)"+
+		") SELECT %[3]s.id FROM tree AS %[3]s", table.Alias, child.Alias, tree.Alias)
+	if sql != st {
+		t.Errorf("bad returned sql. return:\n'%s'\n'%s'", sql, st)
+	}
+}
+
+func TestWith_Queries(t *testing.T) {
+	table := NewTable("table")
+
+	sub := NewSelect()
+	sub.From(table)
+	sub.Column(ColumnName{Table: table, Name: "id"})
+
+	u := NewUpdate(NewTable("table2"))
+	u.With("c", sub)
+	u.Set("col", 1)
+
+	sql, _, err := u.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sql[:len("WITH c AS (SELECT ")] != "WITH c AS (SELECT " {
+		t.Errorf("bad returned sql. return: %s", sql)
+	}
+
+	i := NewInsert(NewTable("table2"))
+	i.With("c", sub)
+	i.Value("col", 1)
+
+	sql, _, err = i.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sql[:len("WITH c AS (SELECT ")] != "WITH c AS (SELECT " {
+		t.Errorf("bad returned sql. return: %s", sql)
+	}
+
+	d := NewDelete(NewTable("table2"))
+	d.With("c", sub)
+	d.Full()
+
+	sql, _, err = d.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sql[:len("WITH c AS (SELECT ")] != "WITH c AS (SELECT " {
+		t.Errorf("bad returned sql. return: %s", sql)
+	}
+}